@@ -0,0 +1,314 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+// defaultBatchChunkSize is the number of executions sent before the driver
+// stops to read their results.
+const defaultBatchChunkSize = 1000
+
+// defaultBatchChunkBytes caps the size of the messages sent for a chunk. The
+// server answers each execution while the rest of the chunk is still being
+// sent, and with nobody reading the answers a long enough chunk leaves both
+// sides blocked writing to each other.
+const defaultBatchChunkBytes = 256 * 1024
+
+// ErrBatchRowSkipped is reported for the rows of a batch that were not executed
+// because an earlier row failed.
+var ErrBatchRowSkipped = errors.New("batch row not executed because an earlier row failed")
+
+// Batch is a statement together with several sets of arguments to execute it
+// with. The executions are pipelined, so the whole batch costs a round trip
+// per chunk of rows rather than one per row. Run it with ExecBatch on the
+// driver connection, reached through sql.Conn.Raw.
+type Batch struct {
+	query      string
+	rows       [][]interface{}
+	chunkSize  int
+	chunkBytes int
+}
+
+// NewBatch returns an empty batch for query. Placeholders are written as for
+// Exec, either positional (?) or named (@name with sql.Named arguments).
+func NewBatch(query string) *Batch {
+	return &Batch{query: query, chunkSize: defaultBatchChunkSize, chunkBytes: defaultBatchChunkBytes}
+}
+
+// Queue adds one execution of the statement with the given arguments.
+func (b *Batch) Queue(args ...interface{}) {
+	b.rows = append(b.rows, args)
+}
+
+// Len returns the number of queued executions.
+func (b *Batch) Len() int {
+	return len(b.rows)
+}
+
+// BatchExecer is implemented by the driver connection. Use it from sql.Conn.Raw.
+type BatchExecer interface {
+	ExecBatch(ctx context.Context, batch *Batch) (*BatchResult, error)
+}
+
+// BatchResult reports the outcome of each queued execution, in queue order.
+type BatchResult struct {
+	// RowsAffected holds the count reported for each execution.
+	RowsAffected []int64
+	// Errors holds nil for each execution that succeeded, the server's error
+	// for the one that failed, and ErrBatchRowSkipped for any after it.
+	Errors []error
+}
+
+// TotalRowsAffected returns the sum of RowsAffected.
+func (r *BatchResult) TotalRowsAffected() int64 {
+	var total int64
+	for _, n := range r.RowsAffected {
+		total += n
+	}
+	return total
+}
+
+// Err returns the error of the execution that failed, or nil if all succeeded.
+func (r *BatchResult) Err() error {
+	for _, err := range r.Errors {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExecBatch prepares the batch's statement and executes it once per queued set
+// of arguments, sending the executions without waiting for each result. After
+// the first failure the remaining executions are skipped. An error is returned
+// without running anything if the statement can't be prepared, returns rows,
+// or an argument set doesn't fit it.
+func (v *connection) ExecBatch(ctx context.Context, batch *Batch) (*BatchResult, error) {
+	if v.activeStream != nil {
+		return nil, errStreamActive
+	}
+
+	s, err := newStmt(v, batch.query)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(s.command) == "" || s.multiStatements {
+		return nil, fmt.Errorf("a batch must contain exactly one statement")
+	}
+	if s.isLocalCopyStatement() {
+		return nil, fmt.Errorf("COPY FROM LOCAL cannot be batched")
+	}
+
+	rowArgs := make([][]driver.NamedValue, len(batch.rows))
+	for idx, args := range batch.rows {
+		if rowArgs[idx], err = s.batchArgs(args); err != nil {
+			return nil, fmt.Errorf("batch row %d: %v", idx, err)
+		}
+	}
+
+	if err = s.prepareAndDescribe(); err != nil {
+		return nil, err
+	}
+	v.lockSessionMutex()
+	defer v.unlockSessionMutex()
+	// Deferred after the unlock so that it runs first, with the session held.
+	defer s.Close()
+
+	// The rows would pile up unread on the server while the chunk is still
+	// being sent, until both sides block writing to each other.
+	if s.returnsRows() {
+		return nil, fmt.Errorf("a batch statement cannot return rows")
+	}
+
+	binds := make([]*msgs.FEBindMsg, len(rowArgs))
+	bindSizes := make([]int, len(rowArgs))
+	for idx := range rowArgs {
+		if binds[idx], err = s.bindMessage("", rowArgs[idx], nil); err != nil {
+			return nil, fmt.Errorf("batch row %d: %v", idx, err)
+		}
+		if err = binds[idx].Validate(); err != nil {
+			return nil, fmt.Errorf("batch row %d: %v", idx, err)
+		}
+		msgBytes, _ := binds[idx].Flatten()
+		bindSizes[idx] = len(msgBytes)
+	}
+
+	doneChan := s.watchForCancel(ctx)
+	v.noticeHandler = noticeHandler(ctx)
+	defer func() {
+		v.noticeHandler = nil
+		doneChan <- true
+	}()
+
+	res := &BatchResult{
+		RowsAffected: make([]int64, len(rowArgs)),
+		Errors:       make([]error, len(rowArgs)),
+	}
+
+	for start, end := 0, 0; start < len(rowArgs); start = end {
+		end = batch.chunkEnd(bindSizes, start)
+		failed, err := s.execBatchChunk(binds[start:end], res.RowsAffected[start:end], res.Errors[start:end])
+		if err != nil {
+			return res, err
+		}
+		if failed {
+			for idx := end; idx < len(rowArgs); idx++ {
+				res.Errors[idx] = ErrBatchRowSkipped
+			}
+			break
+		}
+	}
+
+	if err = v.sync(); err != nil {
+		return res, err
+	}
+	return res, ctx.Err()
+}
+
+// chunkEnd returns the end of the chunk of executions that starts at start:
+// at most chunkSize of them, and no more than chunkBytes of bind messages
+// unless a single one is larger.
+func (b *Batch) chunkEnd(bindSizes []int, start int) int {
+	end, size := start, 0
+	for end < len(bindSizes) && end-start < b.chunkSize {
+		if end > start && size+bindSizes[end] > b.chunkBytes {
+			break
+		}
+		size += bindSizes[end]
+		end++
+	}
+	return end
+}
+
+// returnsRows reports whether the described statement returns rows other than
+// the count that INSERT, UPDATE, DELETE and MERGE report as a single column.
+func (s *stmt) returnsRows() bool {
+	if s.lastRowDesc == nil {
+		return false
+	}
+	command, _, _ := parseCommandTag(s.describedTag)
+	switch command {
+	case "INSERT", "UPDATE", "DELETE", "MERGE":
+		return len(s.lastRowDesc.Columns) != 1
+	}
+	return true
+}
+
+// batchArgs converts one queued argument set the way database/sql would for Exec.
+func (s *stmt) batchArgs(args []interface{}) ([]driver.NamedValue, error) {
+	named := make([]driver.NamedValue, len(args))
+	for idx, arg := range args {
+		nv := driver.NamedValue{Ordinal: idx + 1, Value: arg}
+		if namedArg, ok := arg.(sql.NamedArg); ok {
+			nv.Name, nv.Value = namedArg.Name, namedArg.Value
+		}
+		if err := s.conn.CheckNamedValue(&nv); err != nil {
+			return nil, fmt.Errorf("converting argument %d: %v", idx+1, err)
+		}
+		named[idx] = nv
+	}
+
+	if len(s.namedArgPos) == 0 && len(named) != s.NumInput() {
+		return nil, fmt.Errorf("expected %d arguments, got %d", s.NumInput(), len(named))
+	}
+	return s.injectNamedArgs(named)
+}
+
+// execBatchChunk pipelines one Bind and Execute per row, then reads the results
+// in order. It reports whether a row failed; the server ignores everything
+// after a failure until the next Sync, so the rest of the chunk is skipped.
+func (s *stmt) execBatchChunk(binds []*msgs.FEBindMsg, rowsAffected []int64, rowErrs []error) (bool, error) {
+	for _, bind := range binds {
+		if err := s.conn.sendMessage(bind); err != nil {
+			return false, err
+		}
+		if err := s.conn.sendMessage(&msgs.FEExecuteMsg{}); err != nil {
+			return false, err
+		}
+	}
+	if err := s.conn.sendMessage(&msgs.FEFlushMsg{}); err != nil {
+		return false, err
+	}
+
+	for idx := range binds {
+		gotCount := false
+	readRow:
+		for {
+			bMsg, err := s.conn.recvMessage()
+			if err != nil {
+				return false, err
+			}
+
+			switch msg := bMsg.(type) {
+			case *msgs.BEDataRowMsg:
+				// DML reports the affected row count as a single value.
+				if !gotCount {
+					cols := msg.Columns()
+					if val := cols.Chunk(); val != nil {
+						rowsAffected[idx], _ = strconv.ParseInt(string(val), 10, 64)
+					}
+					gotCount = true
+				}
+			case *msgs.BECmdCompleteMsg:
+				if _, count, ok := parseCommandTag(msg.Tag); ok {
+					rowsAffected[idx] = count
+				}
+				break readRow
+			case *msgs.BEEmptyQueryResponseMsg:
+				break readRow
+			case *msgs.BEErrorMsg:
+				rowErrs[idx] = s.evaluateErrorMsg(msg)
+				for skipped := idx + 1; skipped < len(binds); skipped++ {
+					rowErrs[skipped] = ErrBatchRowSkipped
+				}
+				return true, nil
+			case *msgs.BEBindCompleteMsg, *msgs.BERowDescMsg, *msgs.BECmdDescriptionMsg:
+				continue
+			default:
+				_, _ = s.conn.defaultMessageHandler(msg)
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,173 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestBatchArgs(t *testing.T) {
+	s, _ := newStmt(&connection{}, "INSERT INTO t VALUES (?, ?)")
+
+	args, err := s.batchArgs([]interface{}{1, big.NewRat(1, 4)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args[0].Value != int64(1) || args[1].Value != Decimal("0.25") {
+		t.Errorf("unexpected converted arguments %v", args)
+	}
+
+	if _, err = s.batchArgs([]interface{}{1}); err == nil {
+		t.Errorf("expected an argument count mismatch to be rejected")
+	}
+	if _, err = s.batchArgs([]interface{}{1, struct{}{}}); err == nil {
+		t.Errorf("expected an unsupported argument to be rejected")
+	}
+
+	s, _ = newStmt(&connection{}, "INSERT INTO t VALUES (@b, @a)")
+	args, err = s.batchArgs([]interface{}{sql.Named("a", "x"), sql.Named("b", "y")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args[0].Value != "y" || args[1].Value != "x" {
+		t.Errorf("expected named arguments in placeholder order, got %v", args)
+	}
+}
+
+func TestBatchResult(t *testing.T) {
+	res := &BatchResult{RowsAffected: []int64{1, 2, 0}, Errors: []error{nil, nil, nil}}
+	if res.TotalRowsAffected() != 3 || res.Err() != nil {
+		t.Errorf("unexpected totals %d, %v", res.TotalRowsAffected(), res.Err())
+	}
+	res.Errors[2] = ErrBatchRowSkipped
+	if res.Err() != ErrBatchRowSkipped {
+		t.Errorf("expected the first error, got %v", res.Err())
+	}
+}
+
+func TestBatchChunkEnd(t *testing.T) {
+	batch := &Batch{chunkSize: 3, chunkBytes: 100}
+	sizes := []int{10, 10, 10, 10, 60, 50, 200, 10}
+
+	var ends []int
+	for start, end := 0, 0; start < len(sizes); start = end {
+		end = batch.chunkEnd(sizes, start)
+		ends = append(ends, end)
+	}
+	// Three rows at most, 100 bytes at most, and an oversized row on its own.
+	if !reflect.DeepEqual(ends, []int{3, 5, 6, 7, 8}) {
+		t.Errorf("unexpected chunk ends %v", ends)
+	}
+}
+
+func TestBatchReturnsRows(t *testing.T) {
+	count := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{{FieldName: "OUTPUT"}}}
+	wide := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{{FieldName: "a"}, {FieldName: "b"}}}
+	tests := []struct {
+		tag     string
+		rowDesc *msgs.BERowDescMsg
+		rows    bool
+	}{
+		{"INSERT", count, false},
+		{"UPDATE", count, false},
+		{"COMMIT", nil, false},
+		{"SELECT", count, true},
+		{"SELECT", wide, true},
+		{"INSERT", wide, true},
+	}
+	for _, tc := range tests {
+		s := &stmt{describedTag: tc.tag, lastRowDesc: tc.rowDesc}
+		if got := s.returnsRows(); got != tc.rows {
+			t.Errorf("returnsRows() for %s = %v", tc.tag, got)
+		}
+	}
+}
+
+func TestExecBatchChunkStopsAtFirstError(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	s := &stmt{conn: &connection{conn: client}, preparedName: "S1"}
+	binds := []*msgs.FEBindMsg{
+		{Statement: "S1", NamedArgs: []driver.NamedValue{{Ordinal: 1, Value: int64(1)}}},
+		{Statement: "S1", NamedArgs: []driver.NamedValue{{Ordinal: 1, Value: int64(2)}}},
+		{Statement: "S1", NamedArgs: []driver.NamedValue{{Ordinal: 1, Value: int64(3)}}},
+	}
+
+	go func() {
+		// Every execution is sent before the driver reads anything back.
+		var sent []byte
+		for {
+			msgType, _ := readFrontEndMsg(t, server)
+			sent = append(sent, msgType)
+			if msgType == 'H' || msgType == 0 {
+				break
+			}
+		}
+		if string(sent) != "BEBEBEH" {
+			t.Errorf("unexpected message sequence %q", sent)
+		}
+		writeBackEndMsg(t, server, '2', nil)
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "1"))
+		writeBackEndMsg(t, server, 'C', []byte("INSERT\x00"))
+		writeBackEndMsg(t, server, '2', nil)
+		writeBackEndMsg(t, server, 'E', []byte("SERROR\x00Mduplicate key\x00\x00"))
+	}()
+
+	rowsAffected := make([]int64, 3)
+	rowErrs := make([]error, 3)
+	failed, err := s.execBatchChunk(binds, rowsAffected, rowErrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !failed {
+		t.Fatalf("expected the chunk to report a failure")
+	}
+	if rowsAffected[0] != 1 || rowErrs[0] != nil {
+		t.Errorf("expected the first row to succeed, got %d, %v", rowsAffected[0], rowErrs[0])
+	}
+	if vErr, ok := rowErrs[1].(*VError); !ok || vErr.Message != "duplicate key" {
+		t.Errorf("expected the server error for the second row, got %v", rowErrs[1])
+	}
+	if rowErrs[2] != ErrBatchRowSkipped {
+		t.Errorf("expected the third row to be skipped, got %v", rowErrs[2])
+	}
+}
@@ -0,0 +1,402 @@
+package common
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Complex type OIDs. One-dimensional arrays and sets of a scalar type have
+// their own OIDs; nested arrays, rows and maps use the generic ones.
+const (
+	ColTypeRow   uint32 = 300
+	ColTypeArray uint32 = 301
+	ColTypeMap   uint32 = 302
+
+	colTypeArray1DBase uint32 = 1500
+	colTypeSetBase     uint32 = 2700
+)
+
+// ComplexKind identifies the shape of a ComplexType.
+type ComplexKind int
+
+const (
+	KindScalar ComplexKind = iota
+	KindArray
+	KindSet
+	KindRow
+	KindMap
+)
+
+// ComplexType describes an ARRAY, SET, ROW or MAP column down to its scalar
+// elements. Scalars carry their type OID, which is 0 when the name is unknown.
+type ComplexType struct {
+	Kind    ComplexKind
+	TypeOID uint32
+	Elem    *ComplexType   // array, set and map value type
+	Fields  []ComplexField // row fields in declaration order
+}
+
+// ComplexField is one field of a ROW type.
+type ComplexField struct {
+	Name string
+	Type *ComplexType
+}
+
+// The one-dimensional array and set OIDs are the scalar OID plus a base,
+// except for the types added after the scheme was laid out.
+var collectionElemOffsets = map[uint32]uint32{
+	ColTypeLongVarBinary: 18,
+	ColTypeLongVarChar:   19,
+	ColTypeUUID:          20,
+	ColTypeIntervalYM:    21,
+	ColTypeBinary:        22,
+}
+
+func collectionElemType(typeOID, base uint32) (uint32, bool) {
+	if typeOID <= base || typeOID > base+22 {
+		return 0, false
+	}
+	offset := typeOID - base
+	for elem, off := range collectionElemOffsets {
+		if off == offset {
+			return elem, true
+		}
+	}
+	if offset >= ColTypeBoolean && offset <= ColTypeVarBinary {
+		return offset, true
+	}
+	return 0, false
+}
+
+// ArrayElemType returns the element type of a one-dimensional array OID.
+func ArrayElemType(typeOID uint32) (uint32, bool) {
+	return collectionElemType(typeOID, colTypeArray1DBase)
+}
+
+// SetElemType returns the element type of a set OID.
+func SetElemType(typeOID uint32) (uint32, bool) {
+	return collectionElemType(typeOID, colTypeSetBase)
+}
+
+// IsComplexType reports whether typeOID is an ARRAY, SET, ROW or MAP type.
+func IsComplexType(typeOID uint32) bool {
+	if typeOID == ColTypeRow || typeOID == ColTypeArray || typeOID == ColTypeMap {
+		return true
+	}
+	if _, ok := ArrayElemType(typeOID); ok {
+		return true
+	}
+	_, ok := SetElemType(typeOID)
+	return ok
+}
+
+func complexTypeString(typeOID uint32) (string, bool) {
+	switch typeOID {
+	case ColTypeRow:
+		return "ROW", true
+	case ColTypeArray:
+		return "ARRAY", true
+	case ColTypeMap:
+		return "MAP", true
+	}
+	if elem, ok := ArrayElemType(typeOID); ok {
+		return "ARRAY[" + ColumnTypeString(elem, -1) + "]", true
+	}
+	if elem, ok := SetElemType(typeOID); ok {
+		return "SET[" + ColumnTypeString(elem, -1) + "]", true
+	}
+	return "", false
+}
+
+// ParseComplexType describes a column from its type OID and type name. Names
+// such as "ARRAY[INT]", "Set[Varchar(20)]", "Row(a Int8, b Array[Date])" or
+// "Map<Varchar,Int>" give the full nested structure; when the name does not
+// parse, the OID alone still describes one-dimensional arrays and sets. It
+// returns nil for scalar columns.
+func ParseComplexType(typeOID uint32, typeName string) *ComplexType {
+	if t, err := parseTypeName(typeName); err == nil && t.Kind != KindScalar {
+		return t
+	}
+	if elem, ok := ArrayElemType(typeOID); ok {
+		return &ComplexType{Kind: KindArray, Elem: &ComplexType{TypeOID: elem}}
+	}
+	if elem, ok := SetElemType(typeOID); ok {
+		return &ComplexType{Kind: KindSet, Elem: &ComplexType{TypeOID: elem}}
+	}
+	switch typeOID {
+	case ColTypeArray:
+		return &ComplexType{Kind: KindArray, Elem: &ComplexType{}}
+	case ColTypeRow:
+		return &ComplexType{Kind: KindRow}
+	case ColTypeMap:
+		return &ComplexType{Kind: KindMap, Elem: &ComplexType{}}
+	}
+	return nil
+}
+
+type typeNameParser struct {
+	s   string
+	pos int
+}
+
+func parseTypeName(name string) (*ComplexType, error) {
+	p := &typeNameParser{s: name}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("unexpected %q in type name %q", p.s[p.pos:], name)
+	}
+	return t, nil
+}
+
+func (p *typeNameParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *typeNameParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// keyword consumes word (case-insensitively) when it is followed by open.
+func (p *typeNameParser) keyword(word string, open byte) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end < len(p.s) && strings.EqualFold(p.s[p.pos:end], word) {
+		rest := strings.TrimLeft(p.s[end:], " ")
+		if len(rest) > 0 && rest[0] == open {
+			p.pos = len(p.s) - len(rest) + 1
+			return true
+		}
+	}
+	return false
+}
+
+func (p *typeNameParser) parseType() (*ComplexType, error) {
+	switch {
+	case p.keyword("array", '['):
+		return p.parseCollection(KindArray)
+	case p.keyword("set", '['):
+		return p.parseCollection(KindSet)
+	case p.keyword("row", '('):
+		return p.parseRow()
+	case p.keyword("map", '<'):
+		return p.parseMap()
+	}
+	return p.parseScalar()
+}
+
+func (p *typeNameParser) parseCollection(kind ComplexKind) (*ComplexType, error) {
+	elem, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	// An optional maximum cardinality, e.g. ARRAY[INT, 10].
+	if p.consume(',') {
+		p.skipSpace()
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+	}
+	if !p.consume(']') {
+		return nil, fmt.Errorf("missing ] in type name %q", p.s)
+	}
+	return &ComplexType{Kind: kind, Elem: elem}, nil
+}
+
+func (p *typeNameParser) parseMap() (*ComplexType, error) {
+	if _, err := p.parseType(); err != nil {
+		return nil, err
+	}
+	if !p.consume(',') {
+		return nil, fmt.Errorf("missing map value type in type name %q", p.s)
+	}
+	value, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume('>') {
+		return nil, fmt.Errorf("missing > in type name %q", p.s)
+	}
+	return &ComplexType{Kind: KindMap, Elem: value}, nil
+}
+
+func (p *typeNameParser) parseRow() (*ComplexType, error) {
+	t := &ComplexType{Kind: KindRow}
+	for {
+		name := p.parseFieldName()
+		if name == "" {
+			// Unnamed fields are named f0, f1, ... by the server.
+			name = fmt.Sprintf("f%d", len(t.Fields))
+		}
+		fieldType, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		t.Fields = append(t.Fields, ComplexField{Name: name, Type: fieldType})
+		if p.consume(')') {
+			return t, nil
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("missing ) in type name %q", p.s)
+		}
+	}
+}
+
+// parseFieldName consumes a quoted field name, or an unquoted one when it is
+// followed by a type rather than being the type itself.
+func (p *typeNameParser) parseFieldName() string {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		end := strings.IndexByte(p.s[p.pos+1:], '"')
+		if end >= 0 {
+			name := p.s[p.pos+1 : p.pos+1+end]
+			p.pos += end + 2
+			return name
+		}
+	}
+	start := p.pos
+	end := start
+	for end < len(p.s) && (isIdentByte(p.s[end])) {
+		end++
+	}
+	rest := strings.TrimLeft(p.s[end:], " ")
+	if end == start || len(rest) == 0 || rest[0] == ',' || rest[0] == ')' || rest[0] == '(' || rest[0] == '[' || rest[0] == '<' {
+		return ""
+	}
+	// Multi-word scalar names such as "long varchar" or "timestamp with time
+	// zone" start with a type word.
+	next := strings.ToLower(firstWord(rest))
+	if next == "with" || scalarTypeOID(strings.ToLower(p.s[start:end])+" "+next) != 0 {
+		return ""
+	}
+	p.pos = end
+	return p.s[start:end]
+}
+
+func firstWord(s string) string {
+	end := 0
+	for end < len(s) && isIdentByte(s[end]) {
+		end++
+	}
+	return s[:end]
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *typeNameParser) parseScalar() (*ComplexType, error) {
+	p.skipSpace()
+	start := p.pos
+	depth := 0
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		} else if depth == 0 && (c == ',' || c == ']' || c == '>') {
+			break
+		}
+		p.pos++
+	}
+	name := strings.TrimSpace(p.s[start:p.pos])
+	if name == "" {
+		return nil, fmt.Errorf("missing type in type name %q", p.s)
+	}
+	if paren := strings.IndexByte(name, '('); paren >= 0 {
+		name = strings.TrimSpace(name[:paren])
+	}
+	return &ComplexType{TypeOID: scalarTypeOID(strings.ToLower(name))}, nil
+}
+
+// scalarTypeOID maps a lower case SQL type name to its OID, or 0 if unknown.
+func scalarTypeOID(name string) uint32 {
+	name = strings.Join(strings.Fields(name), " ")
+	switch name {
+	case "bool", "boolean":
+		return ColTypeBoolean
+	case "int", "int8", "integer", "bigint", "smallint", "tinyint":
+		return ColTypeInt64
+	case "float", "float8", "float4", "real", "double precision":
+		return ColTypeFloat64
+	case "char", "character":
+		return ColTypeChar
+	case "varchar", "character varying":
+		return ColTypeVarChar
+	case "date":
+		return ColTypeDate
+	case "time":
+		return ColTypeTime
+	case "timestamp", "datetime", "smalldatetime":
+		return ColTypeTimestamp
+	case "timestamptz", "timestamp with time zone":
+		return ColTypeTimestampTZ
+	case "timetz", "time with time zone":
+		return ColTypeTimeTZ
+	case "numeric", "decimal", "number", "money":
+		return ColTypeNumeric
+	case "varbinary", "binary varying", "bytea", "raw":
+		return ColTypeVarBinary
+	case "uuid":
+		return ColTypeUUID
+	case "long varchar":
+		return ColTypeLongVarChar
+	case "long varbinary":
+		return ColTypeLongVarBinary
+	case "binary":
+		return ColTypeBinary
+	}
+	if strings.HasPrefix(name, "interval") {
+		if strings.Contains(name, "year") || strings.Contains(name, "month") {
+			return ColTypeIntervalYM
+		}
+		return ColTypeInterval
+	}
+	return 0
+}
@@ -93,7 +93,7 @@ func ColumnTypeString(typeOID uint32, typeModifier int32) string {
 	case ColTypeTimestampTZ:
 		return "TIMESTAMPTZ"
 	case ColTypeInterval, ColTypeIntervalYM:
-		return "INTERVAL " + getIntervalRange(typeOID, typeModifier)
+		return "INTERVAL " + IntervalRange(typeOID, typeModifier)
 	case ColTypeTimeTZ:
 		return "TIMETZ"
 	case ColTypeNumeric:
@@ -110,10 +110,16 @@ func ColumnTypeString(typeOID uint32, typeModifier int32) string {
 		return "BINARY"
 	}
 
+	if name, ok := complexTypeString(typeOID); ok {
+		return name
+	}
+
 	return fmt.Sprintf("unknown column type oid: %d", typeOID)
 }
 
-func getIntervalRange(typeOID uint32, typeModifier int32) string {
+// IntervalRange returns the fields of an interval type from its type modifier,
+// such as "DAY TO SECOND" or "YEAR TO MONTH".
+func IntervalRange(typeOID uint32, typeModifier int32) string {
 	const (
 		INTERVAL_MASK_MONTH      = 1 << 17
 		INTERVAL_MASK_YEAR       = 1 << 18
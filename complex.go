@@ -0,0 +1,245 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vertica/vertica-sql-go/common"
+)
+
+// Array holds the elements of an ARRAY or SET column. NULL elements are nil,
+// and nested arrays and rows are Array and Row values.
+type Array []interface{}
+
+// Scan implements sql.Scanner. A NULL column scans as a nil Array.
+func (a *Array) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+	case Array:
+		*a = v
+	case []interface{}:
+		*a = v
+	case string:
+		return a.Scan([]byte(v))
+	case []byte:
+		var r rows
+		val, err := r.decodeComplex(&common.ComplexType{Kind: common.KindArray}, v)
+		if err != nil {
+			return err
+		}
+		arr, ok := val.(Array)
+		if !ok {
+			return fmt.Errorf("cannot scan %q into Array", string(v))
+		}
+		*a = arr
+	default:
+		return fmt.Errorf("cannot scan %T into Array", src)
+	}
+	return nil
+}
+
+// Row holds the fields of a ROW column in declaration order.
+type Row struct {
+	Names  []string
+	Values []interface{}
+}
+
+// Get returns the value of the named field, ignoring case.
+func (r Row) Get(name string) (interface{}, bool) {
+	for idx, fieldName := range r.Names {
+		if strings.EqualFold(fieldName, name) {
+			return r.Values[idx], true
+		}
+	}
+	return nil, false
+}
+
+// Scan implements sql.Scanner. A NULL column scans as a Row with no fields.
+func (r *Row) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Row{}
+	case Row:
+		*r = v
+	case string:
+		return r.Scan([]byte(v))
+	case []byte:
+		var rs rows
+		val, err := rs.decodeComplex(&common.ComplexType{Kind: common.KindRow}, v)
+		if err != nil {
+			return err
+		}
+		row, ok := val.(Row)
+		if !ok {
+			return fmt.Errorf("cannot scan %q into Row", string(v))
+		}
+		*r = row
+	default:
+		return fmt.Errorf("cannot scan %T into Row", src)
+	}
+	return nil
+}
+
+// decodeComplex decodes the JSON text the server sends for ARRAY, SET, ROW and
+// MAP values. Arrays and sets become Array, rows become Row and maps become
+// map[string]interface{}; scalar elements are decoded like columns of their type.
+func (r *rows) decodeComplex(t *common.ComplexType, colVal []byte) (driver.Value, error) {
+	dec := json.NewDecoder(bytes.NewReader(colVal))
+	dec.UseNumber()
+	val, err := r.decodeComplexValue(dec, t)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s value %q: %v", complexKindName(t), string(colVal), err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("cannot decode %s value %q: trailing data", complexKindName(t), string(colVal))
+	}
+	return val, nil
+}
+
+func (r *rows) decodeComplexValue(dec *json.Decoder, t *common.ComplexType) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return v, nil
+	case json.Number:
+		return r.decodeComplexScalar(t, string(v), true)
+	case string:
+		return r.decodeComplexScalar(t, v, false)
+	case json.Delim:
+		if v == '[' {
+			var elem *common.ComplexType
+			if t != nil {
+				elem = t.Elem
+			}
+			arr := Array{}
+			for dec.More() {
+				val, err := r.decodeComplexValue(dec, elem)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, val)
+			}
+			_, err = dec.Token()
+			return arr, err
+		}
+
+		if t != nil && t.Kind == common.KindMap {
+			m := map[string]interface{}{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				if m[key.(string)], err = r.decodeComplexValue(dec, t.Elem); err != nil {
+					return nil, err
+				}
+			}
+			_, err = dec.Token()
+			return m, err
+		}
+
+		row := Row{Names: []string{}, Values: []interface{}{}}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			name := key.(string)
+			val, err := r.decodeComplexValue(dec, rowFieldType(t, name, len(row.Names)))
+			if err != nil {
+				return nil, err
+			}
+			row.Names = append(row.Names, name)
+			row.Values = append(row.Values, val)
+		}
+		_, err = dec.Token()
+		return row, err
+	}
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
+
+// decodeComplexScalar decodes a scalar element. Elements of an unknown type
+// keep their JSON form: strings stay strings and numbers become an int or a
+// float64, the same types scalar INT and FLOAT columns return.
+func (r *rows) decodeComplexScalar(t *common.ComplexType, text string, isNumber bool) (interface{}, error) {
+	if t != nil && t.Kind == common.KindScalar && t.TypeOID != 0 {
+		return r.decodeScalar(t.TypeOID, -1, []byte(text))
+	}
+	if !isNumber {
+		return text, nil
+	}
+	if i, err := strconv.Atoi(text); err == nil {
+		return i, nil
+	}
+	return strconv.ParseFloat(text, 64)
+}
+
+func rowFieldType(t *common.ComplexType, name string, idx int) *common.ComplexType {
+	if t == nil {
+		return nil
+	}
+	for _, field := range t.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field.Type
+		}
+	}
+	if idx < len(t.Fields) {
+		return t.Fields[idx].Type
+	}
+	return nil
+}
+
+func complexKindName(t *common.ComplexType) string {
+	switch t.Kind {
+	case common.KindSet:
+		return "SET"
+	case common.KindRow:
+		return "ROW"
+	case common.KindMap:
+		return "MAP"
+	}
+	return "ARRAY"
+}
@@ -0,0 +1,196 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestParseComplexType(t *testing.T) {
+	scalar := func(oid uint32) *common.ComplexType { return &common.ComplexType{TypeOID: oid} }
+
+	testCases := []struct {
+		name     string
+		typeOID  uint32
+		typeName string
+		expected *common.ComplexType
+	}{
+		{name: "scalar", typeOID: common.ColTypeInt64, typeName: "INT", expected: nil},
+		{name: "1D array OID", typeOID: 1506, typeName: "", expected: &common.ComplexType{Kind: common.KindArray, Elem: scalar(common.ColTypeInt64)}},
+		{name: "set of UUID OID", typeOID: 2720, typeName: "", expected: &common.ComplexType{Kind: common.KindSet, Elem: scalar(common.ColTypeUUID)}},
+		{name: "array of binary OID", typeOID: 1522, typeName: "", expected: &common.ComplexType{Kind: common.KindArray, Elem: scalar(common.ColTypeBinary)}},
+		{name: "generated name", typeOID: 1509, typeName: common.ColumnTypeString(1509, -1), expected: &common.ComplexType{Kind: common.KindArray, Elem: scalar(common.ColTypeVarChar)}},
+		{
+			name: "nested array name", typeOID: common.ColTypeArray, typeName: "Array[Array[Numeric(10,2)], 5]",
+			expected: &common.ComplexType{Kind: common.KindArray, Elem: &common.ComplexType{Kind: common.KindArray, Elem: scalar(common.ColTypeNumeric)}},
+		},
+		{
+			name: "row name", typeOID: common.ColTypeRow, typeName: `Row(id Int8, "Name" Varchar(80), ts Timestamp with time zone, long varchar(10), tags Set[Date])`,
+			expected: &common.ComplexType{Kind: common.KindRow, Fields: []common.ComplexField{
+				{Name: "id", Type: scalar(common.ColTypeInt64)},
+				{Name: "Name", Type: scalar(common.ColTypeVarChar)},
+				{Name: "ts", Type: scalar(common.ColTypeTimestampTZ)},
+				{Name: "f3", Type: scalar(common.ColTypeLongVarChar)},
+				{Name: "tags", Type: &common.ComplexType{Kind: common.KindSet, Elem: scalar(common.ColTypeDate)}},
+			}},
+		},
+		{
+			name: "map name", typeOID: common.ColTypeMap, typeName: "Map<Varchar(10), Interval Year to Month>",
+			expected: &common.ComplexType{Kind: common.KindMap, Elem: scalar(common.ColTypeIntervalYM)},
+		},
+		{name: "user type name only", typeOID: common.ColTypeVarChar, typeName: "ARRAY[BOOLEAN]", expected: &common.ComplexType{Kind: common.KindArray, Elem: scalar(common.ColTypeBoolean)}},
+		{name: "unparsable name", typeOID: common.ColTypeRow, typeName: "Row(", expected: &common.ComplexType{Kind: common.KindRow}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := common.ParseComplexType(tc.typeOID, tc.typeName)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+
+	if name := common.ColumnTypeString(2716, -1); name != "SET[NUMERIC]" {
+		t.Errorf("unexpected type name %q", name)
+	}
+}
+
+func TestComplexColumns(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "ints", DataTypeOID: 1506, DataTypeName: "ARRAY[INT]"},
+		{FieldName: "dates", DataTypeOID: 2710, DataTypeName: "SET[DATE]"},
+		{FieldName: "nested", DataTypeOID: common.ColTypeArray, DataTypeName: "Array[Array[Float]]"},
+		{FieldName: "person", DataTypeOID: common.ColTypeRow, DataTypeName: "Row(name Varchar(20), bytes Varbinary(8), scores Array[Numeric(5,1)], addr Row(zip Int))"},
+		{FieldName: "attrs", DataTypeOID: common.ColTypeMap, DataTypeName: "Map<Varchar, Int>"},
+		{FieldName: "untyped", DataTypeOID: common.ColTypeArray, DataTypeName: ""},
+	}}
+	rows := newTestRows(t, desc, []string{
+		`[1,null,3]`,
+		`["2024-02-29"]`,
+		`[[1.5],[],null]`,
+		`{"name":"ann","bytes":"a\\134b","scores":[9.5,null],"addr":{"zip":12345}}`,
+		`{"a":1,"b":null}`,
+		`["x",2,2.5,true,{"k":"v"}]`,
+	})
+	rows.exactNumeric = true
+
+	result := make([]driver.Value, len(desc.Columns))
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []driver.Value{
+		Array{1, nil, 3},
+		Array{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		Array{Array{1.5}, Array{}, nil},
+		Row{
+			Names: []string{"name", "bytes", "scores", "addr"},
+			Values: []interface{}{"ann", []byte(`a\b`), Array{Decimal("9.5"), nil},
+				Row{Names: []string{"zip"}, Values: []interface{}{12345}}},
+		},
+		map[string]interface{}{"a": 1, "b": nil},
+		Array{"x", 2, 2.5, true, Row{Names: []string{"k"}, Values: []interface{}{"v"}}},
+	}
+	for idx := range expected {
+		if !reflect.DeepEqual(result[idx], expected[idx]) {
+			t.Errorf("column %s: expected %#v, got %#v", desc.Columns[idx].FieldName, expected[idx], result[idx])
+		}
+	}
+
+	scanTypes := []reflect.Type{reflect.TypeOf(Array(nil)), reflect.TypeOf(Array(nil)), reflect.TypeOf(Array(nil)),
+		reflect.TypeOf(Row{}), reflect.TypeOf(map[string]interface{}(nil)), reflect.TypeOf(Array(nil))}
+	for idx, scanType := range scanTypes {
+		if got := rows.ColumnTypeScanType(idx); got != scanType {
+			t.Errorf("column %d: expected scan type %v, got %v", idx, scanType, got)
+		}
+	}
+
+	if zip, ok := result[3].(Row).Get("ADDR"); !ok || !reflect.DeepEqual(zip, expected[3].(Row).Values[3]) {
+		t.Errorf("unexpected field lookup %v, %v", zip, ok)
+	}
+}
+
+func TestComplexColumnMalformed(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "ints", DataTypeOID: 1506, DataTypeName: "ARRAY[INT]"},
+	}}
+	result := make([]driver.Value, 1)
+	for _, text := range []string{`[1,2`, `[1] 2`, `["one"]`} {
+		rows := newTestRows(t, desc, []string{text})
+		if err := rows.Next(result); err == nil {
+			t.Errorf("expected %q to fail to decode, got %#v", text, result[0])
+		}
+	}
+}
+
+func TestScanComplex(t *testing.T) {
+	var a Array
+	if err := a.Scan(Array{1, "b"}); err != nil || !reflect.DeepEqual(a, Array{1, "b"}) {
+		t.Errorf("unexpected scan result %v, %v", a, err)
+	}
+	if err := a.Scan(`[1,[null]]`); err != nil || !reflect.DeepEqual(a, Array{1, Array{nil}}) {
+		t.Errorf("unexpected scan result %v, %v", a, err)
+	}
+	if err := a.Scan(nil); err != nil || a != nil {
+		t.Errorf("expected NULL to scan as a nil Array, got %v, %v", a, err)
+	}
+	if err := a.Scan(`{"a":1}`); err == nil {
+		t.Errorf("expected an object to be rejected")
+	}
+	if err := a.Scan(42); err == nil {
+		t.Errorf("expected an int to be rejected")
+	}
+
+	var r Row
+	if err := r.Scan([]byte(`{"f0":1,"f1":"x"}`)); err != nil || !reflect.DeepEqual(r, Row{Names: []string{"f0", "f1"}, Values: []interface{}{1, "x"}}) {
+		t.Errorf("unexpected scan result %v, %v", r, err)
+	}
+	if v, ok := r.Get("f1"); !ok || v != "x" {
+		t.Errorf("unexpected field lookup %v, %v", v, ok)
+	}
+	if _, ok := r.Get("f2"); ok {
+		t.Errorf("expected a missing field lookup to fail")
+	}
+	if err := r.Scan(nil); err != nil || r.Names != nil {
+		t.Errorf("expected NULL to scan as an empty Row, got %v, %v", r, err)
+	}
+	if err := r.Scan(`[1]`); err == nil {
+		t.Errorf("expected an array to be rejected")
+	}
+}
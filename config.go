@@ -0,0 +1,393 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Connection string query parameters understood by the driver.
+const (
+	dsnUsePreparedStatements = "use_prepared_statements"
+	dsnConnectionLoadBalance = "connection_load_balance"
+	dsnTLSMode               = "tlsmode"
+	dsnBackupServerNode      = "backup_server_node"
+	dsnClientLabel           = "client_label"
+	dsnAutocommit            = "autocommit"
+	dsnOAuthAccessToken      = "oauth_access_token"
+	dsnWorkload              = "workload"
+	dsnTOTP                  = "totp"
+	dsnFetchSize             = "fetch_size"
+	dsnExactNumeric          = "exact_numeric"
+	dsnTypedIntervals        = "typed_intervals"
+	dsnBinaryResults         = "binary_results"
+	dsnBinaryParameters      = "binary_parameters"
+	dsnNaiveTimestamps       = "naive_timestamps"
+	dsnTypedTime             = "typed_time"
+)
+
+// Config holds every option needed to open a connection to Vertica. It can be
+// built directly and handed to NewConnector, or obtained from a connection
+// string with ParseDSN. Use NewConfig to start from the driver defaults, as
+// the zero value disables prepared statements and autocommit.
+type Config struct {
+	User     string
+	Password string
+	// Host is the primary server as a host:port pair. IPv6 literals must be
+	// enclosed in square brackets.
+	Host     string
+	Database string
+
+	// BackupServerNodes lists host:port pairs to try when Host is unreachable.
+	BackupServerNodes []string
+
+	// UsePreparedStatements selects server-side argument binding. When false
+	// the arguments are interpolated into the query on the client.
+	UsePreparedStatements bool
+	ConnectionLoadBalance bool
+	// TLSMode is one of 'none', 'prefer', 'server', 'server-strict' or the
+	// name of a config registered with RegisterTLSConfig. Empty means 'none'.
+	TLSMode string
+	// ClientLabel is shown in the client_label column of v_monitor.sessions.
+	// When empty a label is generated from the driver version and process id.
+	ClientLabel      string
+	Autocommit       bool
+	OAuthAccessToken string
+	Workload         string
+	TOTP             string
+
+	// FetchSize, when positive, makes prepared queries fetch their results from
+	// the server this many rows at a time rather than all at once. Rows are
+	// streamed to the caller as they arrive. See VerticaContext.SetFetchSize.
+	FetchSize int
+
+	// ExactNumeric returns NUMERIC columns as Decimal instead of float64.
+	ExactNumeric bool
+
+	// TypedIntervals returns INTERVAL columns as Interval instead of string.
+	TypedIntervals bool
+
+	// TypedTime returns TIME and TIMETZ columns as Time and TimeTZ instead of
+	// time.Time.
+	TypedTime bool
+
+	// BinaryResults asks for the results of prepared queries in the binary
+	// format, for the column types that have a binary decoder.
+	BinaryResults bool
+
+	// BinaryParameters sends INT, FLOAT, BOOLEAN, DATE, TIMESTAMP, TIMESTAMPTZ
+	// and binary arguments of prepared statements in the binary format.
+	BinaryParameters bool
+
+	// NaiveTimestamps returns TIMESTAMP columns as their wall clock in UTC
+	// instead of resolving them in the session's time zone.
+	NaiveTimestamps bool
+
+	// Types holds type decoders and encoders for the connections opened with
+	// this Config. They take precedence over the ones registered globally with
+	// RegisterTypeDecoder and RegisterTypeEncoder. It has no DSN form.
+	Types *TypeRegistry
+
+	// NoticeHandler is called with each notice the server sends on the
+	// connections opened with this Config, unless the statement was run with a
+	// VerticaContext that has its own. It has no DSN form.
+	NoticeHandler NoticeHandler
+
+	// RetryPolicy runs statements that are safe to run again once more when
+	// they fail with a transient error, unless the statement was run with a
+	// VerticaContext that has its own. It has no DSN form.
+	RetryPolicy *RetryPolicy
+}
+
+// NewConfig returns a Config populated with the driver defaults.
+func NewConfig() *Config {
+	return &Config{
+		UsePreparedStatements: true,
+		Autocommit:            true,
+	}
+}
+
+// ParseDSN parses a connection string of the form
+// vertica://(user):(password)@(host):(port)/(database)[?arg1=value&...&argN=valueN]
+// into a Config. Unknown, repeated or malformed query arguments are reported
+// as errors.
+func ParseDSN(dsn string) (*Config, error) {
+	connURL, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := NewConfig()
+	cfg.Host = connURL.Host
+
+	if connURL.User != nil {
+		cfg.User = connURL.User.Username()
+		cfg.Password, _ = connURL.User.Password()
+	}
+
+	if len(connURL.Path) > 1 {
+		cfg.Database = connURL.Path[1:]
+	}
+
+	query, err := url.ParseQuery(connURL.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection string query: %v", err)
+	}
+
+	for key, values := range query {
+		if len(values) != 1 {
+			return nil, fmt.Errorf("connection parameter %q specified more than once", key)
+		}
+		if err = cfg.setParam(key, values[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) setParam(key, value string) error {
+	var err error
+
+	switch key {
+	case dsnUsePreparedStatements:
+		c.UsePreparedStatements, err = parseDSNBool(key, value, c.UsePreparedStatements)
+	case dsnConnectionLoadBalance:
+		c.ConnectionLoadBalance, err = parseDSNBool(key, value, c.ConnectionLoadBalance)
+	case dsnTLSMode:
+		c.TLSMode = strings.ToLower(value)
+	case dsnBackupServerNode:
+		c.BackupServerNodes = nil
+		if value != "" {
+			c.BackupServerNodes = strings.Split(value, ",")
+		}
+	case dsnClientLabel:
+		c.ClientLabel = value
+	case dsnAutocommit:
+		c.Autocommit, err = parseDSNBool(key, value, c.Autocommit)
+	case dsnOAuthAccessToken:
+		c.OAuthAccessToken = value
+	case dsnWorkload:
+		c.Workload = value
+	case dsnTOTP:
+		c.TOTP = value
+	case dsnFetchSize:
+		c.FetchSize, err = parseDSNNonNegativeInt(key, value)
+	case dsnExactNumeric:
+		c.ExactNumeric, err = parseDSNBool(key, value, c.ExactNumeric)
+	case dsnTypedIntervals:
+		c.TypedIntervals, err = parseDSNBool(key, value, c.TypedIntervals)
+	case dsnBinaryResults:
+		c.BinaryResults, err = parseDSNBool(key, value, c.BinaryResults)
+	case dsnBinaryParameters:
+		c.BinaryParameters, err = parseDSNBool(key, value, c.BinaryParameters)
+	case dsnTypedTime:
+		c.TypedTime, err = parseDSNBool(key, value, c.TypedTime)
+	case dsnNaiveTimestamps:
+		c.NaiveTimestamps, err = parseDSNBool(key, value, c.NaiveTimestamps)
+	default:
+		return fmt.Errorf("unknown connection parameter %q", key)
+	}
+
+	return err
+}
+
+// parseDSNBool accepts the documented 0/1 values as well as anything understood
+// by strconv.ParseBool. An empty value leaves the default in place.
+func parseDSNBool(key, value string, current bool) (bool, error) {
+	if value == "" {
+		return current, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for connection parameter %q: expected 0 or 1", value, key)
+	}
+	return b, nil
+}
+
+func parseDSNNonNegativeInt(key, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid value %q for connection parameter %q: expected a non-negative integer", value, key)
+	}
+	return n, nil
+}
+
+func (c *Config) validate() error {
+	if c.FetchSize < 0 {
+		return fmt.Errorf("invalid fetch size %d: must not be negative", c.FetchSize)
+	}
+	if c.TOTP != "" {
+		if err := validateTOTP(c.TOTP); err != nil {
+			return err
+		}
+	}
+	for _, node := range c.BackupServerNodes {
+		if strings.TrimSpace(node) == "" {
+			return fmt.Errorf("invalid value for connection parameter %q: empty host", dsnBackupServerNode)
+		}
+	}
+	return nil
+}
+
+// FormatDSN returns a connection string that ParseDSN turns back into an
+// equivalent Config. Options left at their defaults are omitted.
+func (c *Config) FormatDSN() string {
+	query := url.Values{}
+
+	if !c.UsePreparedStatements {
+		query.Set(dsnUsePreparedStatements, "0")
+	}
+	if c.ConnectionLoadBalance {
+		query.Set(dsnConnectionLoadBalance, "1")
+	}
+	if c.TLSMode != "" {
+		query.Set(dsnTLSMode, c.TLSMode)
+	}
+	if len(c.BackupServerNodes) > 0 {
+		query.Set(dsnBackupServerNode, strings.Join(c.BackupServerNodes, ","))
+	}
+	if c.ClientLabel != "" {
+		query.Set(dsnClientLabel, c.ClientLabel)
+	}
+	if !c.Autocommit {
+		query.Set(dsnAutocommit, "0")
+	}
+	if c.OAuthAccessToken != "" {
+		query.Set(dsnOAuthAccessToken, c.OAuthAccessToken)
+	}
+	if c.Workload != "" {
+		query.Set(dsnWorkload, c.Workload)
+	}
+	if c.TOTP != "" {
+		query.Set(dsnTOTP, c.TOTP)
+	}
+	if c.FetchSize > 0 {
+		query.Set(dsnFetchSize, strconv.Itoa(c.FetchSize))
+	}
+	if c.ExactNumeric {
+		query.Set(dsnExactNumeric, "1")
+	}
+	if c.TypedIntervals {
+		query.Set(dsnTypedIntervals, "1")
+	}
+	if c.TypedTime {
+		query.Set(dsnTypedTime, "1")
+	}
+	if c.BinaryResults {
+		query.Set(dsnBinaryResults, "1")
+	}
+	if c.BinaryParameters {
+		query.Set(dsnBinaryParameters, "1")
+	}
+	if c.NaiveTimestamps {
+		query.Set(dsnNaiveTimestamps, "1")
+	}
+
+	connURL := url.URL{
+		Scheme:   "vertica",
+		Host:     c.Host,
+		RawQuery: query.Encode(),
+	}
+
+	if c.Password != "" {
+		connURL.User = url.UserPassword(c.User, c.Password)
+	} else if c.User != "" {
+		connURL.User = url.User(c.User)
+	}
+
+	if c.Database != "" {
+		connURL.Path = "/" + c.Database
+	}
+
+	return connURL.String()
+}
+
+// hostname returns the Host without its port, for use as the TLS server name.
+func (c *Config) hostname() string {
+	host, _, err := net.SplitHostPort(c.Host)
+	if err != nil {
+		return strings.TrimSuffix(strings.TrimPrefix(c.Host, "["), "]")
+	}
+	return host
+}
+
+func (c *Config) clone() Config {
+	res := *c
+	res.BackupServerNodes = append([]string(nil), c.BackupServerNodes...)
+	return res
+}
+
+// connector opens connections from a fixed Config.
+// Interface: driver.Connector
+type connector struct {
+	config Config
+	driver *Driver
+}
+
+// NewConnector returns a driver.Connector for the given Config, suitable for
+// use with sql.OpenDB.
+func NewConnector(cfg Config) (driver.Connector, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &connector{config: cfg.clone(), driver: &Driver{}}, nil
+}
+
+// Connect opens a new connection to the database.
+// Interface: driver.Connector
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return newConnectionFromConfig(c.config.clone())
+}
+
+// Driver returns the underlying Driver of the Connector.
+// Interface: driver.Connector
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
@@ -43,7 +43,6 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
-	"net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -53,11 +52,12 @@ import (
 	"github.com/vertica/vertica-sql-go/common"
 	"github.com/vertica/vertica-sql-go/logger"
 	"github.com/vertica/vertica-sql-go/msgs"
+	"github.com/vertica/vertica-sql-go/parse"
 )
 
 var (
-	connectionLogger    = logger.New("connection")
-	asciiTotpRegex      = regexp.MustCompile(`^[0-9]{6}$`) // precompiled: exactly 6 ASCII digits
+	connectionLogger = logger.New("connection")
+	asciiTotpRegex   = regexp.MustCompile(`^[0-9]{6}$`) // precompiled: exactly 6 ASCII digits
 )
 
 const (
@@ -97,12 +97,82 @@ func RegisterTLSConfig(name string, config *tls.Config) error {
 	return tlsConfigs.add(name, config)
 }
 
+// Conn is implemented by the driver's connections. The database/sql package
+// hides them behind its own types, so it is reached through sql.Conn.Raw:
+//
+//	err = conn.Raw(func(driverConn interface{}) error {
+//		pid := driverConn.(vertigo.Conn).BackendPID()
+//		...
+//	})
+//
+// Like the connection itself, its methods must not be called while another
+// statement is running on it, except for Cancel.
+type Conn interface {
+	// BackendPID returns the ID the server gave the session's process.
+	BackendPID() uint32
+
+	// ServerParameters returns the parameters the server reported for the
+	// session, such as its time zone, by name.
+	ServerParameters() map[string]string
+
+	// ServerVersion returns the version of Vertica the session is connected
+	// to, as reported by the server. It is empty if the server did not report it.
+	ServerVersion() string
+
+	// SessionLabel returns the label the session identifies itself with, which
+	// appears as client_label in v_monitor.sessions.
+	SessionLabel() string
+
+	// TransactionStatus returns the state of the session's transaction as of
+	// the last statement.
+	TransactionStatus() TransactionStatus
+
+	// Cancel asks the server to cancel the statement running on the session,
+	// if any.
+	Cancel(ctx context.Context) error
+
+	// SetClientLabel changes the label the session identifies itself with.
+	SetClientLabel(label string) error
+
+	// SetAutocommit turns the session's autocommit on or off.
+	SetAutocommit(autocommit bool) error
+
+	// LastNotice returns the message of the last notice the server sent.
+	LastNotice() string
+}
+
+// TransactionStatus is the state of a session's transaction.
+type TransactionStatus byte
+
+const (
+	// TransactionIdle means the session is not in a transaction block.
+	TransactionIdle TransactionStatus = 'I'
+	// TransactionActive means the session is in a transaction block.
+	TransactionActive TransactionStatus = 'T'
+	// TransactionFailed means the session is in a transaction block that
+	// failed; statements are rejected until it is rolled back.
+	TransactionFailed TransactionStatus = 'E'
+)
+
+func (ts TransactionStatus) String() string {
+	switch ts {
+	case TransactionIdle:
+		return "idle"
+	case TransactionActive:
+		return "active"
+	case TransactionFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown (%q)", byte(ts))
+	}
+}
+
 // Connection represents a connection to Vertica
 type connection struct {
 	driver.Conn
 
 	conn             net.Conn
-	connURL          *url.URL
+	config           Config
 	parameters       map[string]string
 	clientPID        int
 	backendPID       uint32
@@ -114,12 +184,17 @@ type connection struct {
 	sessionID        string
 	autocommit       string
 	oauthaccesstoken string
-	serverTZOffset   string
-	dead             bool // used if a ROLLBACK severity error is encountered
+	serverLocation   *time.Location // the session's time zone, see setServerTimeZone
+	timeZoneStale    bool           // a SET TIME ZONE ran since serverLocation was read
+	dead             bool           // used if a ROLLBACK severity error is encountered
 	sessMutex        sync.Mutex
 	workload         string
 	totp             string
 	lastNotice       string
+	notices          []*Notice     // received since the last statement took them, see takeNotices
+	noticeHandler    NoticeHandler // set by the VerticaContext of the running statement, see handleNotice
+	activeStream     *rowStream    // set while a streamed result owns the session
+	generation       int           // counts the sessions opened by reconnect
 }
 
 // Begin - Begin starts and returns a new transaction. (DEPRECATED)
@@ -140,11 +215,10 @@ func (v *connection) BeginTx(ctx context.Context, opts driver.TxOptions) (driver
 func (v *connection) Close() error {
 	connectionLogger.Trace("connection.Close()")
 
-	v.sendMessage(&msgs.FETerminateMsg{})
-
 	var result error = nil
 
 	if v.conn != nil {
+		v.sendMessage(&msgs.FETerminateMsg{})
 		result = v.conn.Close()
 		v.conn = nil
 	}
@@ -168,6 +242,10 @@ func (v *connection) PrepareContext(ctx context.Context, query string) (driver.S
 		return s, nil
 	}
 
+	if v.activeStream != nil {
+		return nil, errStreamActive
+	}
+
 	// LOCAL COPY statements must use the simple query protocol at execution time,
 	// so skip server-side preparation entirely to avoid orphaned prepared statements.
 	if v.usePreparedStmts && !s.multiStatements && !s.isLocalCopyStatement() {
@@ -219,99 +297,129 @@ func (v *connection) ResetSession(ctx context.Context) error {
 	return v.Ping(ctx)
 }
 
+// CheckNamedValue converts bind arguments into values the driver can send,
+// including driver.Valuer, uint64, json.RawMessage, *big.Int, *big.Rat, net.IP
+// and 16 byte UUID arrays, after applying any registered type encoder.
+// Unsupported types are rejected here rather than sent.
+// Interface: driver.NamedValueChecker
+func (v *connection) CheckNamedValue(nv *driver.NamedValue) error {
+	val, err := checkBindValue(nv.Value, v.config.Types)
+	if err != nil {
+		return err
+	}
+	nv.Value = val
+	return nil
+}
+
 // newConnection constructs a new Vertica Connection object based on the connection string.
 func newConnection(connString string) (*connection, error) {
-
-	result := &connection{parameters: make(map[string]string), usePreparedStmts: true}
-
-	var err error
-	result.connURL, err = url.Parse(connString)
-
+	cfg, err := ParseDSN(connString)
 	if err != nil {
 		return nil, err
 	}
 
+	return newConnectionFromConfig(*cfg)
+}
+
+// newConnectionFromConfig constructs a new Vertica Connection object from a parsed Config.
+func newConnectionFromConfig(cfg Config) (*connection, error) {
+
+	result := &connection{parameters: make(map[string]string), config: cfg}
+
+	var err error
+
 	result.clientPID = os.Getpid()
-	if client_label := result.connURL.Query().Get("client_label"); client_label != "" {
-		result.sessionID = client_label
+	if cfg.ClientLabel != "" {
+		result.sessionID = cfg.ClientLabel
 	} else {
 		result.sessionID = fmt.Sprintf("%s-%s-%d-%d", driverName, driverVersion, result.clientPID, time.Now().Unix())
 	}
 
-	// Read the interpolate flag.
-	if iFlag := result.connURL.Query().Get("use_prepared_statements"); iFlag != "" {
-		result.usePreparedStmts = iFlag == "1"
-	}
+	result.usePreparedStmts = cfg.UsePreparedStatements
 
-	// Read Autocommit flag.
-	if iFlag := result.connURL.Query().Get("autocommit"); iFlag == "" || iFlag == "1" {
+	if cfg.Autocommit {
 		result.autocommit = "on"
 	} else {
 		result.autocommit = "off"
 	}
 
-	// Read OAuth access token flag.
-	result.oauthaccesstoken = result.connURL.Query().Get("oauth_access_token")
+	result.oauthaccesstoken = cfg.OAuthAccessToken
 
-	// Read TOTP (MFA) value. If provided, validate now so we fail fast before handshake.
-	if t := result.connURL.Query().Get("totp"); t != "" {
-		if err := validateTOTP(t); err != nil {
-			return nil, err
-		}
-		result.totp = t
-	}
+	// TOTP has already been validated by the Config, so we fail fast before handshake.
+	result.totp = cfg.TOTP
 
-	// Read connection load balance flag.
-	loadBalanceFlag := result.connURL.Query().Get("connection_load_balance")
+	// Connection failover: push target host to front of the hosts list.
+	result.connHostsList = append([]string{cfg.Host}, cfg.BackupServerNodes...)
 
-	// Read connection failover flag.
-	backupHostsStr := result.connURL.Query().Get("backup_server_node")
-	if backupHostsStr == "" {
-		result.connHostsList = []string{result.connURL.Host}
-	} else {
-		// Parse comma-separated list of backup host-port pairs
-		hosts := strings.Split(backupHostsStr, ",")
-		// Push target host to front of the hosts list
-		result.connHostsList = append([]string{result.connURL.Host}, hosts...)
+	result.workload = cfg.Workload
+
+	if err = result.connect(); err != nil {
+		return nil, err
 	}
 
-	// Read SSL/TLS flag.
-	sslFlag := strings.ToLower(result.connURL.Query().Get("tlsmode"))
+	return result, nil
+}
+
+// connect opens a socket to the server and starts a session on it.
+func (v *connection) connect() error {
+	sslFlag := v.config.TLSMode
 	if sslFlag == "" {
 		sslFlag = tlsModeNone
 	}
 
-	// Read Workload flag
-	result.workload = result.connURL.Query().Get("workload")
-
-	result.conn, err = result.establishSocketConnection()
+	var err error
+	v.conn, err = v.establishSocketConnection()
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Load Balancing
-	if loadBalanceFlag == "1" {
-		if err = result.balanceLoad(); err != nil {
-			return nil, err
+	if v.config.ConnectionLoadBalance {
+		if err = v.balanceLoad(); err != nil {
+			return err
 		}
 	}
 
 	if sslFlag != tlsModeNone {
-		if err = result.initializeSSL(sslFlag); err != nil {
-			return nil, err
+		if err = v.initializeSSL(sslFlag); err != nil {
+			return err
 		}
 	}
 
-	if err = result.handshake(); err != nil {
-		return nil, err
+	if err = v.handshake(); err != nil {
+		return err
 	}
 
-	if err = result.initializeSession(); err != nil {
-		return nil, err
-	}
+	return v.initializeSession()
+}
 
-	return result, nil
+// reconnect replaces a session that was lost with a new one. Statements
+// prepared on the old session are prepared again before they next run, see
+// generation. If no new session can be started the connection is left closed
+// and marked dead, and driver.ErrBadConn is returned so that database/sql
+// drops it from the pool.
+func (v *connection) reconnect() error {
+	if v.conn != nil {
+		_ = v.conn.Close()
+		v.conn = nil
+	}
+	v.parameters = make(map[string]string)
+	v.dead = false
+	v.activeStream = nil
+	v.notices = nil
+	v.generation++
+	connectionLogger.Info("reconnecting to replace a lost session")
+	if err := v.connect(); err != nil {
+		connectionLogger.Error("unable to reconnect: %v", err)
+		if v.conn != nil {
+			_ = v.conn.Close()
+			v.conn = nil
+		}
+		v.dead = true
+		return driver.ErrBadConn
+	}
+	return nil
 }
 
 func (v *connection) establishSocketConnection() (net.Conn, error) {
@@ -382,6 +490,10 @@ func (v *connection) recvMessage() (msgs.BackEndMsg, error) {
 		return nil, err
 	}
 
+	if ready, ok := bem.(*msgs.BEReadyForQueryMsg); ok {
+		v.transactionState = ready.TransactionState
+	}
+
 	// Print the message to stdout (for debugging purposes)
 	if _, drm := bem.(*msgs.BEDataRowMsg); !drm {
 		connectionLogger.Debug("<- " + bem.String())
@@ -393,12 +505,23 @@ func (v *connection) recvMessage() (msgs.BackEndMsg, error) {
 }
 
 func (v *connection) sendMessage(msg msgs.FrontEndMsg) error {
+	if v.conn == nil {
+		// The connection was closed, or lost and not re-established.
+		return driver.ErrBadConn
+	}
 	return v.sendMessageTo(msg, v.conn)
 }
 
 func (v *connection) sendMessageTo(msg msgs.FrontEndMsg, conn net.Conn) error {
 	var result error = nil
 
+	if validator, ok := msg.(msgs.Validator); ok {
+		if result = validator.Validate(); result != nil {
+			connectionLogger.Error("-> NOT SENDING "+msg.String()+": %v", result.Error())
+			return result
+		}
+	}
+
 	msgBytes, msgTag := msg.Flatten()
 
 	if msgTag != 0 {
@@ -443,20 +566,13 @@ func min(a, b int) int {
 
 func (v *connection) handshake() error {
 
-	if v.connURL.User == nil && len(v.oauthaccesstoken) == 0 {
-		return fmt.Errorf("connection string must include a user name or oauth_access_token")
-	}
-
-	userName := v.connURL.User.Username()
+	userName := v.config.User
 
 	if len(userName) == 0 && len(v.oauthaccesstoken) == 0 {
 		return fmt.Errorf("connection string must have a non-empty user name or oauth_access_token")
 	}
 
-	dbName := ""
-	if len(v.connURL.Path) > 1 {
-		dbName = v.connURL.Path[1:]
-	}
+	dbName := v.config.Database
 
 	msg := &msgs.FEStartupMsg{
 		ProtocolVersion:  protocolVersion,
@@ -490,7 +606,7 @@ func (v *connection) handshake() error {
 			v.transactionState = msg.TransactionState
 			return nil
 		case *msgs.BEParamStatusMsg:
-			v.parameters[msg.ParamName] = msg.ParamValue
+			v.paramStatus(msg)
 		case *msgs.BEKeyDataMsg:
 			v.backendPID = msg.BackendPID
 			v.cancelKey = msg.CancelKey
@@ -503,17 +619,36 @@ func (v *connection) handshake() error {
 	}
 }
 
+// querySession runs query as a simple statement without taking the session
+// lock, for the driver's own queries. The caller must hold the session lock,
+// or own the connection while it is being set up.
+func (v *connection) querySession(query string) (*rows, error) {
+	stmt, err := newStmt(v, query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.runSimpleStatement(context.Background(), stmt.command, false)
+}
+
 // We have to be tricky here since we're inside of a connection, but trying to use interfaces of the
 // driver class.
 func (v *connection) initializeSession() error {
-
-	stmt, err := newStmt(v, "select now()::timestamptz")
-
-	if err != nil {
+	if err := v.readTimeZoneOffset(); err != nil {
 		return err
 	}
+	// Without the zone's name the offset read above is used for every value.
+	if err := v.readTimeZoneName(); err != nil {
+		connectionLogger.Warn("unable to read the server timezone: %v", err)
+	}
+	return nil
+}
+
+// readTimeZoneOffset reads the offset of the server's time zone from the
+// current time. It stands in for the time zone until its name is known, and
+// when this client has no rules for the zone.
+func (v *connection) readTimeZoneOffset() error {
 
-	resultRows, err := stmt.QueryContextRaw(context.Background(), []driver.NamedValue{})
+	resultRows, err := v.querySession("select now()::timestamptz")
 	if err != nil {
 		return err
 	}
@@ -534,13 +669,100 @@ func (v *connection) initializeSession() error {
 		return fmt.Errorf("unexpected time value %T", values[0])
 	}
 
-	v.serverTZOffset = timestamp.Format("-07:00")
+	_, offset := timestamp.Zone()
+	v.serverLocation = time.FixedZone(timestamp.Format("-07:00"), offset)
 
-	connectionLogger.Debug("Setting server timezone offset to %s", v.serverTZOffset)
+	connectionLogger.Debug("Setting server timezone offset to %s", timestamp.Format("-07:00"))
 
 	return nil
 }
 
+// readTimeZoneName reads the name of the session's time zone, which resolves
+// each value with the offset in effect at its date.
+func (v *connection) readTimeZoneName() error {
+	resultRows, err := v.querySession("SHOW TIMEZONE")
+	if err != nil {
+		return err
+	}
+	defer resultRows.Close()
+
+	// SHOW returns the name of the parameter and its setting.
+	values := make([]driver.Value, len(resultRows.Columns()))
+	if len(values) == 0 {
+		return fmt.Errorf("unexpected empty result")
+	}
+	if err := resultRows.Next(values); err != nil {
+		return err
+	}
+	name, ok := values[len(values)-1].(string)
+	if !ok {
+		return fmt.Errorf("unexpected time zone value %T", values[len(values)-1])
+	}
+	v.setServerTimeZone(name)
+
+	return nil
+}
+
+// paramStatus records a server parameter and tracks the ones the driver
+// depends on.
+func (v *connection) paramStatus(msg *msgs.BEParamStatusMsg) {
+	if v.parameters == nil {
+		v.parameters = make(map[string]string)
+	}
+	v.parameters[msg.ParamName] = msg.ParamValue
+	if strings.EqualFold(msg.ParamName, "timezone") {
+		v.setServerTimeZone(msg.ParamValue)
+	}
+}
+
+// setServerTimeZone resolves TIMESTAMP and TIME values in the named zone from
+// now on. Names this client has no rules for, such as a bare offset, leave the
+// current zone in place.
+func (v *connection) setServerTimeZone(name string) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		connectionLogger.Debug("Keeping server timezone %s: %v", v.serverLocation, err)
+		return
+	}
+	v.serverLocation = loc
+	v.timeZoneStale = false
+	connectionLogger.Debug("Setting server timezone to %s", loc)
+}
+
+// refreshTimeZone reads the session's time zone again after a SET TIME ZONE.
+// It must be called holding the session lock.
+func (v *connection) refreshTimeZone() {
+	if !v.timeZoneStale {
+		return
+	}
+	v.timeZoneStale = false
+	if err := v.initializeSession(); err != nil {
+		connectionLogger.Warn("unable to refresh the server timezone: %v", err)
+	}
+}
+
+// setsTimeZone reports whether a command may change the session's time zone.
+// Literals and comments are ignored.
+func setsTimeZone(command string) bool {
+	for _, statement := range parse.SplitStatements(command) {
+		tokens := topLevelSQLTokens(statement)
+		if len(tokens) < 2 || tokens[0].text != "SET" {
+			continue
+		}
+		tokens = tokens[1:]
+		if tokens[0].text == "SESSION" {
+			tokens = tokens[1:]
+		}
+		if len(tokens) > 0 && tokens[0].text == "TIMEZONE" {
+			return true
+		}
+		if len(tokens) > 1 && tokens[0].text == "TIME" && tokens[1].text == "ZONE" {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *connection) defaultMessageHandler(bMsg msgs.BackEndMsg) (bool, error) {
 
 	handled := true
@@ -569,8 +791,10 @@ func (v *connection) defaultMessageHandler(bMsg msgs.BackEndMsg) (bool, error) {
 		// Capture NOTICE text so tests (like MFA secret retrieval) can parse it
 		v.lastNotice = msg.Message
 		connectionLogger.Info("NOTICE: %s", msg.Message)
+		v.handleNotice(noticeMsgToNotice(msg))
 	case *msgs.BEParamStatusMsg:
 		connectionLogger.Debug("%v", msg)
+		v.paramStatus(msg)
 	case *msgs.BEParseCompleteMsg:
 		connectionLogger.Trace("parse complete")
 	default:
@@ -647,7 +871,7 @@ func (v *connection) balanceLoad() error {
 	connectionLogger.Debug("<- " + bem.String())
 	msg := bem.(*msgs.BELoadBalanceMsg)
 
-	// v.connURL.Hostname() is used by initializeSSL(), so load balancing info should not write into v.connURL
+	// v.config.hostname() is used by initializeSSL(), so load balancing info should not write into v.config
 	loadBalanceAddr := fmt.Sprintf("%s:%d", msg.Host, msg.Port)
 
 	if v.connHostsList[0] == loadBalanceAddr {
@@ -702,7 +926,7 @@ func (v *connection) initializeSSL(sslFlag string) error {
 		v.conn = tls.Client(v.conn, &tls.Config{InsecureSkipVerify: true})
 	case tlsModeServerStrict:
 		connectionLogger.Info("enabling SSL/TLS server strict mode")
-		v.conn = tls.Client(v.conn, &tls.Config{ServerName: v.connURL.Hostname()})
+		v.conn = tls.Client(v.conn, &tls.Config{ServerName: v.config.hostname()})
 	default:
 		// Custom mode is used for mutual ssl mode
 		connectionLogger.Info("enabling SSL/TLS custom mode")
@@ -720,11 +944,7 @@ func (v *connection) initializeSSL(sslFlag string) error {
 }
 
 func (v *connection) authSendPlainTextPassword() error {
-	passwd, isSet := v.connURL.User.Password()
-
-	if !isSet {
-		passwd = ""
-	}
+	passwd := v.config.Password
 
 	msg := &msgs.FEPasswordMsg{PasswordData: passwd}
 
@@ -732,13 +952,9 @@ func (v *connection) authSendPlainTextPassword() error {
 }
 
 func (v *connection) authSendMD5Password(extraAuthData []byte) error {
-	passwd, isSet := v.connURL.User.Password()
-
-	if !isSet {
-		passwd = ""
-	}
+	passwd := v.config.Password
 
-	hash1 := fmt.Sprintf("%x", md5.Sum([]byte(passwd+v.connURL.User.Username())))
+	hash1 := fmt.Sprintf("%x", md5.Sum([]byte(passwd+v.config.User)))
 	hash2 := fmt.Sprintf("md5%x", md5.Sum(append([]byte(hash1), extraAuthData[0:4]...)))
 
 	msg := &msgs.FEPasswordMsg{PasswordData: hash2}
@@ -747,11 +963,7 @@ func (v *connection) authSendMD5Password(extraAuthData []byte) error {
 }
 
 func (v *connection) authSendSHA512Password(extraAuthData []byte) error {
-	passwd, isSet := v.connURL.User.Password()
-
-	if !isSet {
-		passwd = ""
-	}
+	passwd := v.config.Password
 
 	hash1 := fmt.Sprintf("%x", sha512.Sum512(append([]byte(passwd), extraAuthData[8:]...)))
 	hash2 := fmt.Sprintf("sha512%x", sha512.Sum512(append([]byte(hash1), extraAuthData[0:4]...)))
@@ -853,7 +1065,124 @@ func (v *connection) drainUntilReady() error {
 }
 
 func (v *connection) LastNotice() string {
-    return v.lastNotice
+	return v.lastNotice
+}
+
+// BackendPID returns the ID the server gave the session's process.
+func (v *connection) BackendPID() uint32 {
+	return v.backendPID
+}
+
+// ServerParameters returns a copy of the parameters the server reported.
+func (v *connection) ServerParameters() map[string]string {
+	params := make(map[string]string, len(v.parameters))
+	for name, value := range v.parameters {
+		params[name] = value
+	}
+	return params
+}
+
+// ServerVersion returns the version of Vertica the server reported.
+func (v *connection) ServerVersion() string {
+	return v.parameters["server_version"]
+}
+
+// SessionLabel returns the label the session identifies itself with.
+func (v *connection) SessionLabel() string {
+	return v.sessionID
+}
+
+// TransactionStatus returns the state of the session's transaction.
+func (v *connection) TransactionStatus() TransactionStatus {
+	return TransactionStatus(v.transactionState)
+}
+
+// Cancel sends a cancel request for the session on a connection of its own,
+// as the session's one is busy with the statement to cancel.
+func (v *connection) Cancel(ctx context.Context) error {
+	return v.cancelSession(ctx, v.backendPID, v.cancelKey)
+}
+
+// cancelSession sends a cancel request for the session with the given backend
+// PID and cancel key. Callers that may outlive the session pass the values it
+// had when they started, so that a session opened by reconnect is left alone.
+func (v *connection) cancelSession(ctx context.Context, pid, key uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	conn, err := v.establishSocketConnection()
+	if err != nil {
+		return fmt.Errorf("unable to establish connection for cancellation: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(10 * time.Second)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	return v.sendMessageTo(&msgs.FECancelMsg{PID: pid, Key: key}, conn)
+}
+
+// SetClientLabel changes the label the session identifies itself with.
+func (v *connection) SetClientLabel(label string) error {
+	if err := v.execInternal("SELECT SET_CLIENT_LABEL(?)", label); err != nil {
+		return err
+	}
+	v.sessionID = label
+	return nil
+}
+
+// SetAutocommit turns the session's autocommit on or off.
+func (v *connection) SetAutocommit(autocommit bool) error {
+	setting := "off"
+	if autocommit {
+		setting = "on"
+	}
+	if err := v.execInternal("SET SESSION AUTOCOMMIT TO " + setting); err != nil {
+		return err
+	}
+	v.autocommit = setting
+	return nil
+}
+
+// execInternal runs a statement on behalf of the driver, discarding its result.
+func (v *connection) execInternal(query string, args ...interface{}) error {
+	stmt, err := v.PrepareContext(context.Background(), query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	_, err = stmt.(driver.StmtExecContext).ExecContext(context.Background(), named)
+	return err
+}
+
+// handleNotice keeps a notice for the running statement and passes it to the
+// notice handler of the statement's VerticaContext, or failing that to the one
+// of the connector.
+func (v *connection) handleNotice(notice *Notice) {
+	v.notices = append(v.notices, notice)
+	switch {
+	case v.noticeHandler != nil:
+		v.noticeHandler(notice)
+	case v.config.NoticeHandler != nil:
+		v.config.NoticeHandler(notice)
+	}
+}
+
+// takeNotices returns the notices received since it was last called, which
+// belong to the statement that has just run.
+func (v *connection) takeNotices() []*Notice {
+	notices := v.notices
+	v.notices = nil
+	return notices
 }
 
 func (v *connection) lockSessionMutex() {
@@ -0,0 +1,97 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"net"
+	"testing"
+
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestConnParameters(t *testing.T) {
+	conn := &connection{parameters: make(map[string]string), backendPID: 4242, sessionID: "my-app", transactionState: 'T'}
+	conn.paramStatus(&msgs.BEParamStatusMsg{ParamName: "server_version", ParamValue: "v24.1.0-0"})
+	conn.paramStatus(&msgs.BEParamStatusMsg{ParamName: "timezone", ParamValue: "Europe/Paris"})
+
+	var vConn Conn = conn
+	if vConn.BackendPID() != 4242 {
+		t.Errorf("BackendPID() = %d", vConn.BackendPID())
+	}
+	if vConn.ServerVersion() != "v24.1.0-0" {
+		t.Errorf("ServerVersion() = %q", vConn.ServerVersion())
+	}
+	if vConn.SessionLabel() != "my-app" {
+		t.Errorf("SessionLabel() = %q", vConn.SessionLabel())
+	}
+	if vConn.TransactionStatus() != TransactionActive {
+		t.Errorf("TransactionStatus() = %v", vConn.TransactionStatus())
+	}
+
+	params := vConn.ServerParameters()
+	if len(params) != 2 || params["timezone"] != "Europe/Paris" {
+		t.Errorf("unexpected parameters %v", params)
+	}
+	params["timezone"] = "UTC"
+	if vConn.ServerParameters()["timezone"] != "Europe/Paris" {
+		t.Error("ServerParameters did not return a copy")
+	}
+}
+
+func TestTransactionStatusString(t *testing.T) {
+	tests := map[TransactionStatus]string{
+		TransactionIdle:   "idle",
+		TransactionActive: "active",
+		TransactionFailed: "failed",
+		'X':               "unknown ('X')",
+	}
+	for status, want := range tests {
+		if status.String() != want {
+			t.Errorf("%q.String() = %q, want %q", byte(status), status.String(), want)
+		}
+	}
+}
+
+func TestSendMessageValidates(t *testing.T) {
+	// Nothing reads the pipe, so a write would block the test.
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	v := &connection{conn: client}
+	bind := &msgs.FEBindMsg{NamedArgs: []driver.NamedValue{{Ordinal: 1, Value: uint32(1)}}}
+	if err := v.sendMessage(bind); err == nil {
+		t.Error("expected the unsupported argument to be rejected")
+	}
+}
@@ -44,6 +44,12 @@ const (
 	stdInDefaultCopyBlockSize = 65536
 )
 
+// CopyWriteFileFunc returns the destination for a file that COPY writes on the client, such as the target of
+// REJECTED DATA or EXCEPTIONS in a COPY ... FROM LOCAL statement. The fileName is the path given in the
+// statement, or empty for the rejected row numbers returned by RETURNREJECTED, which are written one per line.
+// If the writer is also an io.Closer it is closed when the statement finishes.
+type CopyWriteFileFunc func(fileName string) (io.Writer, error)
+
 type VerticaContext interface {
 	context.Context
 
@@ -53,8 +59,48 @@ type VerticaContext interface {
 	SetCopyBlockSizeBytes(blockSize int) error
 	GetCopyBlockSizeBytes() int
 
+	SetCopyWriteFileFunc(writeFile CopyWriteFileFunc) error
+	GetCopyWriteFileFunc() CopyWriteFileFunc
+
 	SetInMemoryResultRowLimit(rowLimit int) error
 	GetInMemoryResultRowLimit() int
+
+	SetStreamResults(stream bool) error
+	GetStreamResults() bool
+
+	SetFetchSize(fetchSize int) error
+	GetFetchSize() int
+
+	SetExactNumeric(exact bool) error
+	GetExactNumeric() bool
+
+	SetTypedIntervals(typed bool) error
+	GetTypedIntervals() bool
+
+	SetTypedTime(typed bool) error
+	GetTypedTime() bool
+
+	SetBinaryResults(binary bool) error
+	GetBinaryResults() bool
+
+	SetNaiveTimestamps(naive bool) error
+	GetNaiveTimestamps() bool
+
+	SetLenientDecoding(lenient bool) error
+	GetLenientDecoding() bool
+	DecodeErrors() []*DecodeError
+
+	SetContinueOnError(continueOnError bool) error
+	GetContinueOnError() bool
+
+	SetNoticeHandler(handler NoticeHandler) error
+	GetNoticeHandler() NoticeHandler
+
+	SetRetryPolicy(policy *RetryPolicy) error
+	GetRetryPolicy() *RetryPolicy
+
+	SetIdempotent(idempotent bool) error
+	GetIdempotent() bool
 }
 
 type verticaContext struct {
@@ -62,7 +108,21 @@ type verticaContext struct {
 
 	inputStream io.Reader
 	blockSize   int
+	writeFile   CopyWriteFileFunc
 	rowLimit    int
+	stream      bool
+	fetchSize   int
+	exact       bool
+	intervals   bool
+	timeOfDay   bool
+	binary      bool
+	naive       bool
+	lenient     bool
+	decodeErrs  decodeErrorLog
+	continueErr bool
+	onNotice    NoticeHandler
+	retry       *RetryPolicy
+	idempotent  bool
 }
 
 // NewVerticaContext creates a new context that inherits the values and behavior of the provided parent context.
@@ -109,6 +169,19 @@ func (c *verticaContext) GetCopyBlockSizeBytes() int {
 	return c.blockSize
 }
 
+// SetCopyWriteFileFunc routes the files COPY writes on the client to the writers returned by writeFile instead
+// of the local file system. Set it to nil to restore the default, which appends to the named files.
+func (c *verticaContext) SetCopyWriteFileFunc(writeFile CopyWriteFileFunc) error {
+	c.writeFile = writeFile
+
+	return nil
+}
+
+// GetCopyWriteFileFunc returns the function used to open files COPY writes on the client, or nil for the default.
+func (c *verticaContext) GetCopyWriteFileFunc() CopyWriteFileFunc {
+	return c.writeFile
+}
+
 func (c *verticaContext) SetInMemoryResultRowLimit(rowLimit int) error {
 	if rowLimit < 0 {
 		return fmt.Errorf("cannot set result limit to a negative number")
@@ -122,3 +195,179 @@ func (c *verticaContext) SetInMemoryResultRowLimit(rowLimit int) error {
 func (c *verticaContext) GetInMemoryResultRowLimit() int {
 	return c.rowLimit
 }
+
+// SetStreamResults makes queries run with this context read rows from the server as rows.Next asks for them,
+// rather than buffering the whole result before the query returns. The connection is busy until the result
+// is exhausted or closed, so other statements on the same connection fail in the meantime. Closing a result
+// early reads the rows the server has already sent; once more than a few remain, the statement is canceled,
+// which takes a connection of its own. Set a fetch size to read only to the end of the current page instead.
+func (c *verticaContext) SetStreamResults(stream bool) error {
+	c.stream = stream
+
+	return nil
+}
+
+// GetStreamResults reports whether query results are streamed from the server.
+func (c *verticaContext) GetStreamResults() bool {
+	return c.stream
+}
+
+// SetFetchSize makes prepared queries run with this context fetch their results from the server fetchSize rows
+// at a time. The rows are streamed as with SetStreamResults. A value of 0 (the default) uses the fetch_size
+// connection parameter.
+func (c *verticaContext) SetFetchSize(fetchSize int) error {
+	if fetchSize < 0 {
+		return fmt.Errorf("cannot set fetch size to a negative number")
+	}
+
+	c.fetchSize = fetchSize
+
+	return nil
+}
+
+// GetFetchSize returns the number of rows fetched from the server at a time, or 0 if not set on this context.
+func (c *verticaContext) GetFetchSize() int {
+	return c.fetchSize
+}
+
+// SetExactNumeric makes queries run with this context return NUMERIC columns as Decimal instead of float64, as
+// the exact_numeric connection parameter does for every query.
+func (c *verticaContext) SetExactNumeric(exact bool) error {
+	c.exact = exact
+
+	return nil
+}
+
+// GetExactNumeric reports whether NUMERIC columns are returned as Decimal for queries run with this context.
+func (c *verticaContext) GetExactNumeric() bool {
+	return c.exact
+}
+
+// SetTypedIntervals makes queries run with this context return INTERVAL columns as Interval instead of string, as
+// the typed_intervals connection parameter does for every query.
+func (c *verticaContext) SetTypedIntervals(typed bool) error {
+	c.intervals = typed
+
+	return nil
+}
+
+// GetTypedIntervals reports whether INTERVAL columns are returned as Interval for queries run with this context.
+func (c *verticaContext) GetTypedIntervals() bool {
+	return c.intervals
+}
+
+// SetTypedTime makes queries run with this context return TIME and TIMETZ columns as Time and TimeTZ instead of
+// time.Time, as the typed_time connection parameter does for every query.
+func (c *verticaContext) SetTypedTime(typed bool) error {
+	c.timeOfDay = typed
+
+	return nil
+}
+
+// GetTypedTime reports whether TIME and TIMETZ columns are returned as Time and TimeTZ for queries run with this
+// context.
+func (c *verticaContext) GetTypedTime() bool {
+	return c.timeOfDay
+}
+
+// SetBinaryResults makes prepared queries run with this context fetch their results in the binary format, as the
+// binary_results connection parameter does for every query.
+func (c *verticaContext) SetBinaryResults(binary bool) error {
+	c.binary = binary
+
+	return nil
+}
+
+// GetBinaryResults reports whether results are fetched in the binary format for queries run with this context.
+func (c *verticaContext) GetBinaryResults() bool {
+	return c.binary
+}
+
+// SetNaiveTimestamps makes queries run with this context return TIMESTAMP columns as their wall clock in UTC, as
+// the naive_timestamps connection parameter does for every query.
+func (c *verticaContext) SetNaiveTimestamps(naive bool) error {
+	c.naive = naive
+
+	return nil
+}
+
+// GetNaiveTimestamps reports whether TIMESTAMP columns are returned in UTC for queries run with this context.
+func (c *verticaContext) GetNaiveTimestamps() bool {
+	return c.naive
+}
+
+// SetLenientDecoding makes queries run with this context return NULL for values that cannot be decoded, instead
+// of stopping at the first one with a *DecodeError. The errors are recorded and returned by DecodeErrors.
+func (c *verticaContext) SetLenientDecoding(lenient bool) error {
+	c.lenient = lenient
+
+	return nil
+}
+
+// GetLenientDecoding reports whether values that cannot be decoded are returned as NULL for queries run with
+// this context.
+func (c *verticaContext) GetLenientDecoding() bool {
+	return c.lenient
+}
+
+// DecodeErrors returns the decode errors of every lenient query run with this context so far, in the order the
+// values were read.
+func (c *verticaContext) DecodeErrors() []*DecodeError {
+	return c.decodeErrs.list()
+}
+
+// SetContinueOnError makes a string of several statements run with this context carry on past the statements that
+// fail, instead of stopping at the first one. The failures are returned together as a *ScriptError once every
+// statement has run. It has no effect on a single statement. database/sql drops the result of an Exec that
+// returns an error, so the outcome of each statement is only reachable by running it through sql.Conn.Raw.
+func (c *verticaContext) SetContinueOnError(continueOnError bool) error {
+	c.continueErr = continueOnError
+
+	return nil
+}
+
+// GetContinueOnError reports whether a string of several statements run with this context carries on past the
+// statements that fail.
+func (c *verticaContext) GetContinueOnError() bool {
+	return c.continueErr
+}
+
+// SetNoticeHandler sets the function called with each notice the server sends while a statement run with this
+// context executes. It takes the place of the connector's Config.NoticeHandler for those statements.
+func (c *verticaContext) SetNoticeHandler(handler NoticeHandler) error {
+	c.onNotice = handler
+
+	return nil
+}
+
+// GetNoticeHandler returns the function called with the notices of statements run with this context, if any.
+func (c *verticaContext) GetNoticeHandler() NoticeHandler {
+	return c.onNotice
+}
+
+// SetRetryPolicy sets the retry policy of statements run with this context. It takes the place of the connector's
+// Config.RetryPolicy for those statements.
+func (c *verticaContext) SetRetryPolicy(policy *RetryPolicy) error {
+	c.retry = policy
+
+	return nil
+}
+
+// GetRetryPolicy returns the retry policy of statements run with this context, if any.
+func (c *verticaContext) GetRetryPolicy() *RetryPolicy {
+	return c.retry
+}
+
+// SetIdempotent marks the statements run with this context as safe to run more than once, so that a retry policy
+// applies to them even if they are not SELECTs or the session's autocommit is off. They are still not retried inside
+// a transaction.
+func (c *verticaContext) SetIdempotent(idempotent bool) error {
+	c.idempotent = idempotent
+
+	return nil
+}
+
+// GetIdempotent reports whether the statements run with this context are marked as safe to run more than once.
+func (c *verticaContext) GetIdempotent() bool {
+	return c.idempotent
+}
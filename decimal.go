@@ -0,0 +1,170 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// Decimal is an exact decimal number kept in its text form, such as "-12.3400".
+// With exact_numeric enabled, NUMERIC columns are returned as Decimal rather
+// than float64. A Decimal, or a *big.Rat with a terminating decimal expansion,
+// can be used as a query argument without losing precision.
+type Decimal string
+
+// NewDecimalFromRat returns the exact decimal form of r. It fails if r has no
+// finite decimal expansion, such as 1/3.
+func NewDecimalFromRat(r *big.Rat) (Decimal, error) {
+	if r.IsInt() {
+		return Decimal(r.Num().String()), nil
+	}
+
+	// A fraction terminates only when its denominator has no prime factors
+	// other than 2 and 5. The digits needed are the larger of the two powers.
+	denom := new(big.Int).Set(r.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	mod := new(big.Int)
+	twos, fives := 0, 0
+	for mod.Mod(denom, two).Sign() == 0 {
+		denom.Quo(denom, two)
+		twos++
+	}
+	for mod.Mod(denom, five).Sign() == 0 {
+		denom.Quo(denom, five)
+		fives++
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return "", fmt.Errorf("%s has no exact decimal representation", r.String())
+	}
+
+	digits := twos
+	if fives > digits {
+		digits = fives
+	}
+	return Decimal(r.FloatString(digits)), nil
+}
+
+func (d Decimal) validate() error {
+	if !decimalPattern.MatchString(string(d)) {
+		return fmt.Errorf("invalid decimal value %q", string(d))
+	}
+	return nil
+}
+
+// String returns the decimal text.
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// Rat returns the value as a *big.Rat.
+func (d Decimal) Rat() (*big.Rat, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(string(d))
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal value %q", string(d))
+	}
+	return r, nil
+}
+
+// Value returns the decimal text.
+// Interface: driver.Valuer
+func (d Decimal) Value() (driver.Value, error) {
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+	return string(d), nil
+}
+
+// Scan reads a NUMERIC, integer or text value. Use NullDecimal for nullable columns.
+// Interface: sql.Scanner
+func (d *Decimal) Scan(src interface{}) error {
+	var val Decimal
+	switch v := src.(type) {
+	case Decimal:
+		val = v
+	case string:
+		val = Decimal(v)
+	case []byte:
+		val = Decimal(v)
+	case int64:
+		val = Decimal(strconv.FormatInt(v, 10))
+	case int:
+		val = Decimal(strconv.Itoa(v))
+	case float64:
+		val = Decimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Decimal, use NullDecimal instead")
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", src)
+	}
+	if err := val.validate(); err != nil {
+		return err
+	}
+	*d = val
+	return nil
+}
+
+// NullDecimal is a Decimal that may be NULL.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool // Valid is true if Decimal is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		n.Decimal, n.Valid = "", false
+		return nil
+	}
+	if err := n.Decimal.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
@@ -0,0 +1,138 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"testing"
+)
+
+func TestNewDecimalFromRat(t *testing.T) {
+	var testCases = []struct {
+		rat      *big.Rat
+		expected Decimal
+	}{
+		{big.NewRat(42, 1), "42"},
+		{big.NewRat(-7, 2), "-3.5"},
+		{big.NewRat(1, 8), "0.125"},
+		{big.NewRat(3, 40), "0.075"},
+		{new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)), "0.000000000000000000000000000001"},
+	}
+	for _, tc := range testCases {
+		d, err := NewDecimalFromRat(tc.rat)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", tc.rat, err)
+		}
+		if d != tc.expected {
+			t.Errorf("expected %s for %v, got %s", tc.expected, tc.rat, d)
+		}
+	}
+
+	if _, err := NewDecimalFromRat(big.NewRat(1, 3)); err == nil {
+		t.Errorf("expected 1/3 to be rejected")
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	var d Decimal
+	for src, expected := range map[interface{}]Decimal{
+		"-12.3400":       "-12.3400",
+		Decimal(".5"):    ".5",
+		int64(17):        "17",
+		float64(2.25):    "2.25",
+		"1.5e10":         "1.5e10",
+		"+0000123.00100": "+0000123.00100",
+	} {
+		if err := d.Scan(src); err != nil {
+			t.Fatalf("unexpected error scanning %v: %v", src, err)
+		}
+		if d != expected {
+			t.Errorf("expected %s, got %s", expected, d)
+		}
+	}
+
+	for _, src := range []interface{}{nil, "abc", "1.2.3", "", true} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("expected an error scanning %v", src)
+		}
+	}
+
+	r, err := Decimal("-1.25").Rat()
+	if err != nil || r.Cmp(big.NewRat(-5, 4)) != 0 {
+		t.Errorf("unexpected Rat result %v, %v", r, err)
+	}
+}
+
+func TestNullDecimal(t *testing.T) {
+	var n NullDecimal
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("expected a NULL scan to be invalid, got %+v, %v", n, err)
+	}
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("expected nil value, got %v, %v", v, err)
+	}
+	if err := n.Scan([]byte("99.99")); err != nil || !n.Valid || n.Decimal != "99.99" {
+		t.Fatalf("unexpected scan result %+v, %v", n, err)
+	}
+	if v, err := n.Value(); v != "99.99" || err != nil {
+		t.Errorf("expected 99.99, got %v, %v", v, err)
+	}
+}
+
+func TestCheckNamedValueDecimal(t *testing.T) {
+	conn := &connection{}
+
+	nv := &driver.NamedValue{Value: big.NewRat(5, 4)}
+	if err := conn.CheckNamedValue(nv); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nv.Value != Decimal("1.25") {
+		t.Errorf("expected *big.Rat to become Decimal(1.25), got %T(%v)", nv.Value, nv.Value)
+	}
+
+	if err := conn.CheckNamedValue(&driver.NamedValue{Value: Decimal("1,25")}); err == nil {
+		t.Errorf("expected a malformed Decimal to be rejected")
+	}
+	if err := conn.CheckNamedValue(&driver.NamedValue{Value: big.NewRat(2, 3)}); err == nil {
+		t.Errorf("expected a repeating fraction to be rejected")
+	}
+
+	bound, err := encodeBindArgs([]driver.NamedValue{{Value: Decimal("3.14")}, {Value: int64(1)}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bound[0].Value != "3.14" || bound[1].Value != int64(1) {
+		t.Errorf("unexpected bind values %v", bound)
+	}
+}
@@ -60,6 +60,17 @@ func (d *Driver) Open(connString string) (driver.Conn, error) {
 	return conn, err
 }
 
+// OpenConnector parses the connection string once so that sql.DB can open new
+// connections without re-parsing it.
+// Interface: driver.DriverContext
+func (d *Driver) OpenConnector(connString string) (driver.Connector, error) {
+	cfg, err := ParseDSN(connString)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{config: *cfg, driver: d}, nil
+}
+
 // Register ourselves with the sql package.
 func init() {
 	logger.SetLogLevel(logger.WARN)
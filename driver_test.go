@@ -33,15 +33,22 @@ package vertigo
 // THE SOFTWARE.
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -605,6 +612,135 @@ func TestBasicExec(t *testing.T) {
 	assertNoErr(t, err)
 }
 
+func TestExecCommandTag(t *testing.T) {
+	connDB := openConnection(t, "test_basic_exec_pre")
+	defer closeConnection(t, connDB, "test_basic_exec_post")
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	exec := func(query string) (res Result) {
+		rawErr := conn.Raw(func(driverConn interface{}) error {
+			stmt, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+			if err != nil {
+				return err
+			}
+			defer stmt.Close()
+			driverRes, err := stmt.(driver.StmtExecContext).ExecContext(ctx, nil)
+			if err != nil {
+				return err
+			}
+			res = driverRes.(Result)
+			return nil
+		})
+		assertNoErr(t, rawErr)
+		return res
+	}
+
+	res := exec("INSERT INTO MyTable VALUES (1, 'a')")
+	assertEqual(t, res.Command(), "INSERT")
+	ct, err := res.RowsAffected()
+	assertNoErr(t, err)
+	assertEqual(t, ct, int64(1))
+
+	res = exec("INSERT INTO MyTable SELECT id + 1, name FROM MyTable")
+	ct, err = res.RowsAffected()
+	assertNoErr(t, err)
+	assertEqual(t, ct, int64(1))
+
+	res = exec("UPDATE MyTable SET name = 'b'")
+	assertEqual(t, res.Command(), "UPDATE")
+	ct, err = res.RowsAffected()
+	assertNoErr(t, err)
+	assertEqual(t, ct, int64(2))
+
+	// DDL returns no rows and affects none.
+	res = exec("CREATE TABLE IF NOT EXISTS MyOtherTable (id int)")
+	ct, err = res.RowsAffected()
+	assertNoErr(t, err)
+	assertEqual(t, ct, int64(0))
+	_ = exec("DROP TABLE IF EXISTS MyOtherTable")
+}
+
+func TestExecStatementResults(t *testing.T) {
+	simpleConnStr := strings.Replace(myDBConnectString, "use_prepared_statements=1", "use_prepared_statements=0", 1)
+	connDB, err := sql.Open("vertica", simpleConnStr)
+	assertNoErr(t, err)
+	defer closeConnection(t, connDB, "test_basic_exec_post")
+	assertExecSQL(t, connDB, "test_basic_exec_pre")
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	var res Result
+	rawErr := conn.Raw(func(driverConn interface{}) error {
+		stmt, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx,
+			"INSERT INTO MyTable VALUES (1, 'a'); INSERT INTO MyTable VALUES (2, 'b'); UPDATE MyTable SET name = 'c'")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		driverRes, err := stmt.(driver.StmtExecContext).ExecContext(ctx, nil)
+		if err != nil {
+			return err
+		}
+		res = driverRes.(Result)
+		return nil
+	})
+	assertNoErr(t, rawErr)
+
+	statements := res.Statements()
+	assertEqual(t, len(statements), 3)
+	assertEqual(t, statements[0].Statement, "INSERT INTO MyTable VALUES (1, 'a')")
+	assertEqual(t, statements[0].Command, "INSERT")
+	assertEqual(t, statements[0].RowsAffected, int64(1))
+	assertEqual(t, statements[1].RowsAffected, int64(1))
+	assertEqual(t, statements[2].Command, "UPDATE")
+	assertEqual(t, statements[2].RowsAffected, int64(2))
+
+	ct, err := res.RowsAffected()
+	assertNoErr(t, err)
+	assertEqual(t, ct, int64(4))
+}
+
+func TestContinueOnError(t *testing.T) {
+	simpleConnStr := strings.Replace(myDBConnectString, "use_prepared_statements=1", "use_prepared_statements=0", 1)
+	connDB, err := sql.Open("vertica", simpleConnStr)
+	assertNoErr(t, err)
+	defer closeConnection(t, connDB, "test_basic_exec_post")
+	assertExecSQL(t, connDB, "test_basic_exec_pre")
+
+	script := "INSERT INTO MyTable VALUES (1, 'a'); INSERT INTO MissingTable VALUES (2, 'b'); INSERT INTO MyTable VALUES (3, 'c')"
+
+	// By default the first failure stops the script.
+	_, err = connDB.ExecContext(ctx, script)
+	if _, ok := err.(*VError); !ok {
+		t.Fatalf("expected a *VError, got %v", err)
+	}
+	var count int
+	assertNoErr(t, connDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM MyTable").Scan(&count))
+	assertEqual(t, count, 1)
+	_, err = connDB.ExecContext(ctx, "DELETE FROM MyTable")
+	assertNoErr(t, err)
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetContinueOnError(true))
+	_, err = connDB.ExecContext(vCtx, script)
+	scriptErr, ok := err.(*ScriptError)
+	if !ok {
+		t.Fatalf("expected a *ScriptError, got %v", err)
+	}
+	assertEqual(t, len(scriptErr.Errors), 1)
+	assertEqual(t, scriptErr.Errors[0].Index, 1)
+	assertEqual(t, scriptErr.Errors[0].Statement, "INSERT INTO MissingTable VALUES (2, 'b')")
+	assertEqual(t, scriptErr.Errors[0].Err.SQLState, "42V01")
+
+	assertNoErr(t, connDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM MyTable").Scan(&count))
+	assertEqual(t, count, 2)
+}
+
 func TestBasicArgsQuery(t *testing.T) {
 	connDB := openConnection(t, "test_basic_args_query_pre")
 	defer closeConnection(t, connDB, "test_basic_args_query_post")
@@ -1637,6 +1773,46 @@ func TestLocalCopyPipeDelimited(t *testing.T) {
 	assertNoNext(t, rows)
 }
 
+func TestLocalCopyRejectedDataOnClient(t *testing.T) {
+	connDB := openConnection(t, "test_copy_local_pipe_pre")
+	defer closeConnection(t, connDB, "test_copy_local_pipe_post")
+
+	dataPath, err := filepath.Abs("./resources/pipe/sample_data.psv")
+	assertNoErr(t, err)
+
+	// With the wrong delimiter every row is rejected.
+	rejectsPath := filepath.Join(t.TempDir(), "rejects.txt")
+	copySQL := fmt.Sprintf(
+		"COPY pipe_values FROM LOCAL '%s' DELIMITER ',' REJECTED DATA '%s'",
+		filepath.ToSlash(dataPath),
+		filepath.ToSlash(rejectsPath),
+	)
+	_, err = connDB.ExecContext(ctx, copySQL)
+	assertNoErr(t, err)
+
+	rejects, err := ioutil.ReadFile(rejectsPath)
+	assertNoErr(t, err)
+	assertEqual(t, strings.Count(string(rejects), "\n"), 5)
+
+	// The same output can be routed elsewhere through the context.
+	outputs := map[string]*strings.Builder{}
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetCopyWriteFileFunc(func(fileName string) (io.Writer, error) {
+		outputs[fileName] = &strings.Builder{}
+		return outputs[fileName], nil
+	}))
+	copySQL = fmt.Sprintf(
+		"COPY pipe_values FROM LOCAL '%s' DELIMITER ',' REJECTED DATA 'rejects' EXCEPTIONS 'exceptions'",
+		filepath.ToSlash(dataPath),
+	)
+	_, err = connDB.ExecContext(vCtx, copySQL)
+	assertNoErr(t, err)
+	assertEqual(t, outputs["rejects"].String(), string(rejects))
+	if outputs["exceptions"] == nil || outputs["exceptions"].Len() == 0 {
+		t.Fatal("expected exceptions to be written")
+	}
+}
+
 func TestLocalCopyTSV(t *testing.T) {
 	connDB := openConnection(t, "test_copy_local_tsv_pre")
 	defer closeConnection(t, connDB, "test_copy_local_tsv_post")
@@ -1781,6 +1957,346 @@ func TestEnableResultCache(t *testing.T) {
 	testEnableResultCachePageSized(t, connDB, vCtx, 0)
 }
 
+func TestStreamResults(t *testing.T) {
+	connDB := openConnection(t, "test_enable_result_cache_pre")
+	defer closeConnection(t, connDB, "test_enable_result_cache_post")
+
+	vCtx := NewVerticaContext(context.Background())
+	assertNoErr(t, vCtx.SetStreamResults(true))
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(vCtx, "SELECT a, b FROM result_cache_test ORDER BY a")
+	assertNoErr(t, err)
+
+	var a int
+	var b string
+	assertNext(t, rows)
+	assertNoErr(t, rows.Scan(&a, &b))
+	assertEqual(t, a, 1)
+
+	// The rest of the result is still on the wire, so the session is busy.
+	_, err = conn.QueryContext(ctx, "SELECT 1")
+	assertErr(t, err, "streamed result set is still open")
+
+	count := 1
+	for rows.Next() {
+		count++
+		assertNoErr(t, rows.Scan(&a, &b))
+		assertEqual(t, a, count)
+	}
+	assertNoErr(t, rows.Err())
+	assertEqual(t, count, 42)
+	assertNoErr(t, rows.Close())
+
+	// Closing part way through drains the rest of the result.
+	rows, err = conn.QueryContext(vCtx, "SELECT a FROM result_cache_test ORDER BY a")
+	assertNoErr(t, err)
+	assertNext(t, rows)
+	assertNoErr(t, rows.Close())
+
+	var one int
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT 1").Scan(&one))
+	assertEqual(t, one, 1)
+}
+
+func TestFetchSize(t *testing.T) {
+	connDB := openConnection(t, "test_enable_result_cache_pre")
+	defer closeConnection(t, connDB, "test_enable_result_cache_post")
+
+	vCtx := NewVerticaContext(context.Background())
+
+	for _, fetchSize := range []int{1, 5, 41, 42, 100} {
+		assertNoErr(t, vCtx.SetFetchSize(fetchSize))
+
+		rows, err := connDB.QueryContext(vCtx, "SELECT a FROM result_cache_test ORDER BY a")
+		assertNoErr(t, err)
+
+		var a, count int
+		for rows.Next() {
+			count++
+			assertNoErr(t, rows.Scan(&a))
+			assertEqual(t, a, count)
+		}
+		assertNoErr(t, rows.Err())
+		assertNoErr(t, rows.Close())
+		assertEqual(t, count, 42)
+	}
+
+	// Stop part way through a page; the portal is closed and the connection
+	// remains usable.
+	assertNoErr(t, vCtx.SetFetchSize(10))
+	rows, err := connDB.QueryContext(vCtx, "SELECT a FROM result_cache_test ORDER BY a")
+	assertNoErr(t, err)
+	assertNext(t, rows)
+	assertNoErr(t, rows.Close())
+
+	// DML through a named portal still reports the affected rows.
+	res, err := connDB.ExecContext(vCtx, "UPDATE result_cache_test SET e = 457 WHERE a <= ?", 3)
+	assertNoErr(t, err)
+	affected, err := res.RowsAffected()
+	assertNoErr(t, err)
+	assertEqual(t, affected, int64(3))
+}
+
+func TestExactNumeric(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	const value = "1234567890123456789012345678.0123456789"
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetExactNumeric(true))
+
+	var d Decimal
+	err := connDB.QueryRowContext(vCtx, "SELECT "+value+"::NUMERIC(38,10)").Scan(&d)
+	assertNoErr(t, err)
+	assertEqual(t, d, Decimal(value))
+
+	// Bound values keep every digit on the way in as well.
+	var n NullDecimal
+	err = connDB.QueryRowContext(vCtx, "SELECT ?::NUMERIC(38,10)", Decimal(value)).Scan(&n)
+	assertNoErr(t, err)
+	assertEqual(t, n, NullDecimal{Decimal: value, Valid: true})
+
+	r, _ := new(big.Rat).SetString("-0.0000000001")
+	err = connDB.QueryRowContext(vCtx, "SELECT ?::NUMERIC(38,10)", r).Scan(&d)
+	assertNoErr(t, err)
+	assertEqual(t, d, Decimal("-0.0000000001"))
+
+	err = connDB.QueryRowContext(vCtx, "SELECT NULL::NUMERIC(38,10)").Scan(&n)
+	assertNoErr(t, err)
+	assertEqual(t, n.Valid, false)
+
+	// Without the option NUMERIC is still a float64.
+	var f interface{}
+	err = connDB.QueryRowContext(ctx, "SELECT 1.5::NUMERIC(38,10)").Scan(&f)
+	assertNoErr(t, err)
+	assertEqual(t, f, 1.5)
+}
+
+func TestExtendedBindTypes(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	var s string
+	err := connDB.QueryRowContext(ctx, "SELECT ?::VARCHAR", json.RawMessage(`{"a":1}`)).Scan(&s)
+	assertNoErr(t, err)
+	assertEqual(t, s, `{"a":1}`)
+
+	err = connDB.QueryRowContext(ctx, "SELECT ?::VARCHAR", net.ParseIP("10.0.0.1")).Scan(&s)
+	assertNoErr(t, err)
+	assertEqual(t, s, "10.0.0.1")
+
+	id := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	err = connDB.QueryRowContext(ctx, "SELECT ?::UUID::VARCHAR", id).Scan(&s)
+	assertNoErr(t, err)
+	assertEqual(t, s, "123e4567-e89b-12d3-a456-426614174000")
+
+	var i int64
+	err = connDB.QueryRowContext(ctx, "SELECT ?::INT", uint64(42)).Scan(&i)
+	assertNoErr(t, err)
+	assertEqual(t, i, int64(42))
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetExactNumeric(true))
+	var d Decimal
+	err = connDB.QueryRowContext(vCtx, "SELECT ?::NUMERIC(38,0)", uint64(18446744073709551615)).Scan(&d)
+	assertNoErr(t, err)
+	assertEqual(t, d, Decimal("18446744073709551615"))
+
+	big100 := new(big.Int).Lsh(big.NewInt(1), 100)
+	err = connDB.QueryRowContext(vCtx, "SELECT ?::NUMERIC(38,0)", big100).Scan(&d)
+	assertNoErr(t, err)
+	assertEqual(t, d, Decimal(big100.String()))
+
+	// Types the driver cannot represent fail before anything is sent.
+	_, err = connDB.ExecContext(ctx, "SELECT ?", struct{}{})
+	assertErr(t, err, "unsupported argument type")
+}
+
+func TestComplexTypes(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	var ints Array
+	err := connDB.QueryRowContext(ctx, "SELECT ARRAY[1, NULL, 3]").Scan(&ints)
+	assertNoErr(t, err)
+	assertEqual(t, ints, Array{1, nil, 3})
+
+	var nested Array
+	err = connDB.QueryRowContext(ctx, "SELECT ARRAY[ARRAY['a', 'b'], ARRAY['c']]").Scan(&nested)
+	assertNoErr(t, err)
+	assertEqual(t, nested, Array{Array{"a", "b"}, Array{"c"}})
+
+	var set Array
+	err = connDB.QueryRowContext(ctx, "SELECT SET['2024-02-29'::DATE]").Scan(&set)
+	assertNoErr(t, err)
+	assertEqual(t, set, Array{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)})
+
+	var row Row
+	err = connDB.QueryRowContext(ctx, "SELECT ROW(1 AS id, 'ann' AS name, ARRAY[1.5, 2.5] AS scores)").Scan(&row)
+	assertNoErr(t, err)
+	assertEqual(t, row, Row{Names: []string{"id", "name", "scores"}, Values: []interface{}{1, "ann", Array{1.5, 2.5}}})
+
+	err = connDB.QueryRowContext(ctx, "SELECT NULL::ARRAY[INT]").Scan(&ints)
+	assertNoErr(t, err)
+	assertEqual(t, ints == nil, true)
+}
+
+func TestArrayParameters(t *testing.T) {
+	connDB := openConnection(t, "test_array_params_pre")
+	defer closeConnection(t, connDB, "test_array_params_post")
+
+	days := []time.Time{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)}
+	_, err := connDB.ExecContext(ctx, "INSERT INTO array_params_test VALUES (?, ?, ?)", 1, []string{`say "hi"`, `back\slash`}, days)
+	assertNoErr(t, err)
+	_, err = connDB.ExecContext(ctx, "INSERT INTO array_params_test VALUES (?, ?, ?)", 2, Array{"a", nil}, nil)
+	assertNoErr(t, err)
+
+	var tags Array
+	err = connDB.QueryRowContext(ctx, "SELECT tags FROM array_params_test WHERE id = 1").Scan(&tags)
+	assertNoErr(t, err)
+	assertEqual(t, tags, Array{`say "hi"`, `back\slash`})
+
+	var set Array
+	err = connDB.QueryRowContext(ctx, "SELECT days FROM array_params_test WHERE id = 1").Scan(&set)
+	assertNoErr(t, err)
+	assertEqual(t, set, Array{days[0]})
+
+	err = connDB.QueryRowContext(ctx, "SELECT tags FROM array_params_test WHERE id = 2").Scan(&tags)
+	assertNoErr(t, err)
+	assertEqual(t, tags, Array{"a", nil})
+
+	var count int
+	err = connDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM array_params_test WHERE id = ANY(?::ARRAY[INT])", []int64{1, 2, 3}).Scan(&count)
+	assertNoErr(t, err)
+	assertEqual(t, count, 2)
+}
+
+func TestTypedIntervals(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetTypedIntervals(true))
+
+	var iv Interval
+	err := connDB.QueryRowContext(vCtx, "SELECT INTERVAL '-1 02:03:04.5'").Scan(&iv)
+	assertNoErr(t, err)
+	d, err := iv.Duration()
+	assertNoErr(t, err)
+	assertEqual(t, d, -(26*time.Hour + 3*time.Minute + 4500*time.Millisecond))
+
+	err = connDB.QueryRowContext(vCtx, "SELECT INTERVAL '1-2' YEAR TO MONTH").Scan(&iv)
+	assertNoErr(t, err)
+	assertEqual(t, iv, Interval{Months: 14})
+
+	// Durations and Intervals bind as interval literals.
+	err = connDB.QueryRowContext(vCtx, "SELECT ?::INTERVAL MINUTE", 90*time.Minute).Scan(&iv)
+	assertNoErr(t, err)
+	assertEqual(t, iv, Interval{Microseconds: 90 * int64(time.Minute/time.Microsecond)})
+
+	var n NullInterval
+	err = connDB.QueryRowContext(vCtx, "SELECT ?::INTERVAL YEAR TO MONTH", Interval{Months: -30}).Scan(&n)
+	assertNoErr(t, err)
+	assertEqual(t, n, NullInterval{Interval: Interval{Months: -30}, Valid: true})
+
+	// Without the option intervals are still strings.
+	var s string
+	err = connDB.QueryRowContext(ctx, "SELECT INTERVAL '1 02:03:04'").Scan(&s)
+	assertNoErr(t, err)
+	assertEqual(t, s, "1 02:03:04")
+}
+
+func TestBinaryResults(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	const query = "SELECT ?::INT, 2.5::FLOAT, true, 12.3456::NUMERIC(20,4), '2024-02-29'::DATE, " +
+		"'2024-02-29 13:14:15.5'::TIMESTAMP, '2024-02-29 13:14:15.5+00'::TIMESTAMPTZ, '13:14:15'::TIME, " +
+		"HEX_TO_BINARY('0x00ff')::VARBINARY, 'text', ARRAY[1, 2]"
+
+	scanAll := func(c context.Context) []interface{} {
+		values := make([]interface{}, 11)
+		ptrs := make([]interface{}, len(values))
+		for idx := range values {
+			ptrs[idx] = &values[idx]
+		}
+		assertNoErr(t, connDB.QueryRowContext(c, query, 42).Scan(ptrs...))
+		return values
+	}
+
+	text := scanAll(ctx)
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetBinaryResults(true))
+	binary := scanAll(vCtx)
+
+	for idx := range text {
+		if tt, ok := text[idx].(time.Time); ok {
+			if !tt.Equal(binary[idx].(time.Time)) {
+				t.Errorf("column %d: expected %v, got %v", idx, tt, binary[idx])
+			}
+			continue
+		}
+		assertEqual(t, binary[idx], text[idx])
+	}
+}
+
+func TestExecBatch(t *testing.T) {
+	connDB := openConnection(t, "test_exec_batch_pre")
+	defer closeConnection(t, connDB, "test_exec_batch_post")
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	execBatch := func(batch *Batch) (res *BatchResult, err error) {
+		rawErr := conn.Raw(func(driverConn interface{}) error {
+			res, err = driverConn.(BatchExecer).ExecBatch(ctx, batch)
+			return nil
+		})
+		assertNoErr(t, rawErr)
+		return res, err
+	}
+
+	batch := NewBatch("INSERT INTO batch_test VALUES (?, ?)")
+	for i := 0; i < 2500; i++ {
+		batch.Queue(i, fmt.Sprintf("row %d", i))
+	}
+	res, err := execBatch(batch)
+	assertNoErr(t, err)
+	assertNoErr(t, res.Err())
+	assertEqual(t, res.TotalRowsAffected(), int64(2500))
+
+	var count int
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM batch_test").Scan(&count))
+	assertEqual(t, count, 2500)
+
+	// A duplicate key stops the batch; the rows after it are not executed.
+	batch = NewBatch("INSERT INTO batch_test VALUES (@id, @name)")
+	batch.Queue(sql.Named("id", 5000), sql.Named("name", "new"))
+	batch.Queue(sql.Named("id", 1), sql.Named("name", "duplicate"))
+	batch.Queue(sql.Named("id", 5001), sql.Named("name", "skipped"))
+	res, err = execBatch(batch)
+	assertNoErr(t, err)
+	assertEqual(t, res.Errors[0], nil)
+	assertErr(t, res.Errors[1], "Duplicate key values")
+	assertEqual(t, res.Errors[2], ErrBatchRowSkipped)
+
+	// The connection is ready for more work.
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM batch_test WHERE id = 5001").Scan(&count))
+	assertEqual(t, count, 0)
+
+	// A query is rejected before anything runs.
+	batch = NewBatch("SELECT * FROM batch_test WHERE id = ?")
+	batch.Queue(1)
+	_, err = execBatch(batch)
+	assertErr(t, err, "cannot return rows")
+}
+
 //func TestConnectionClosure(t *testing.T) {
 // 	adminDB := openConnection(t, "test_connection_closed_pre")
 // 	defer closeConnection(t, adminDB, "test_connection_closed_post")
@@ -2200,3 +2716,374 @@ func init() {
 
 	ctx = context.Background()
 }
+
+func TestBinaryParameters(t *testing.T) {
+	cfg, err := ParseDSN(myDBConnectString)
+	assertNoErr(t, err)
+	cfg.BinaryParameters = true
+	connector, err := NewConnector(*cfg)
+	assertNoErr(t, err)
+	connDB := sql.OpenDB(connector)
+	defer closeConnection(t, connDB)
+
+	ts := time.Date(2024, 2, 29, 13, 14, 15, 500000000, time.UTC)
+	blob := bytes.Repeat([]byte{'\\', 0, 0xff}, 4096)
+
+	var (
+		i    int64
+		f    float64
+		b    bool
+		tstz time.Time
+		d    time.Time
+		bin  []byte
+	)
+	err = connDB.QueryRowContext(ctx, "SELECT ?::INT, ?::FLOAT, ?::BOOLEAN, ?::TIMESTAMPTZ, ?::DATE, ?::LONG VARBINARY",
+		int64(math.MinInt64+1), 0.1, true, ts, ts, blob).Scan(&i, &f, &b, &tstz, &d, &bin)
+	assertNoErr(t, err)
+	assertEqual(t, i, int64(math.MinInt64+1))
+	assertEqual(t, f, 0.1)
+	assertEqual(t, b, true)
+	if !tstz.Equal(ts) {
+		t.Errorf("expected %v, got %v", ts, tstz)
+	}
+	assertEqual(t, d.Format("2006-01-02"), "2024-02-29")
+	if !bytes.Equal(bin, blob) {
+		t.Errorf("binary argument did not round trip: got %d bytes", len(bin))
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	// A five digit year is a valid DATE that time.Time cannot be parsed from.
+	const query = "SELECT '12345-06-07'::DATE AS day, 42 AS n"
+
+	var (
+		day interface{}
+		n   int
+	)
+	err := connDB.QueryRowContext(ctx, query).Scan(&day, &n)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a *DecodeError, got %v", err)
+	}
+	assertEqual(t, decodeErr.Column, 0)
+	assertEqual(t, decodeErr.ColumnName, "day")
+	assertEqual(t, string(decodeErr.Raw), "12345-06-07")
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetLenientDecoding(true))
+	assertNoErr(t, connDB.QueryRowContext(vCtx, query).Scan(&day, &n))
+	assertEqual(t, day, nil)
+	assertEqual(t, n, 42)
+	if errs := vCtx.DecodeErrors(); len(errs) != 1 || errs[0].ColumnName != "day" {
+		t.Errorf("unexpected decode errors %v", errs)
+	}
+}
+
+func TestInfinityTimestamps(t *testing.T) {
+	connDB := openConnection(t, "test_infinity_pre")
+	defer closeConnection(t, connDB, "test_infinity_post")
+
+	_, err := connDB.ExecContext(ctx, "INSERT INTO infinity_test VALUES (1, ?, ?, ?)",
+		NegativeInfinity, PositiveInfinity, PositiveInfinity)
+	assertNoErr(t, err)
+
+	var validFrom, validTo, day time.Time
+	err = connDB.QueryRowContext(ctx, "SELECT valid_from, valid_to, day FROM infinity_test WHERE id = 1").Scan(&validFrom, &validTo, &day)
+	assertNoErr(t, err)
+	assertEqual(t, validFrom, NegativeInfinity)
+	assertEqual(t, validTo, PositiveInfinity)
+	assertEqual(t, day, PositiveInfinity)
+
+	var count int
+	err = connDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM infinity_test WHERE valid_to = ?", PositiveInfinity).Scan(&count)
+	assertNoErr(t, err)
+	assertEqual(t, count, 1)
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetBinaryResults(true))
+	err = connDB.QueryRowContext(vCtx, "SELECT valid_from, valid_to, day FROM infinity_test WHERE id = ?", 1).Scan(&validFrom, &validTo, &day)
+	assertNoErr(t, err)
+	assertEqual(t, validFrom, NegativeInfinity)
+	assertEqual(t, validTo, PositiveInfinity)
+	assertEqual(t, day, PositiveInfinity)
+}
+
+func TestTimeZoneTracking(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SET TIME ZONE TO 'America/New_York'")
+	assertNoErr(t, err)
+
+	var winter, summer time.Time
+	err = conn.QueryRowContext(ctx, "SELECT '2024-01-15 12:00:00'::TIMESTAMP, '2024-07-15 12:00:00'::TIMESTAMP").Scan(&winter, &summer)
+	assertNoErr(t, err)
+	assertEqual(t, winter.UTC(), time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC))
+	assertEqual(t, summer.UTC(), time.Date(2024, 7, 15, 16, 0, 0, 0, time.UTC))
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetNaiveTimestamps(true))
+	err = conn.QueryRowContext(vCtx, "SELECT '2024-07-15 12:00:00'::TIMESTAMP").Scan(&summer)
+	assertNoErr(t, err)
+	assertEqual(t, summer, time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC))
+}
+
+func TestTypedTime(t *testing.T) {
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetTypedTime(true))
+
+	var (
+		opens  Time
+		closes TimeTZ
+		empty  NullTime
+	)
+	err := connDB.QueryRowContext(vCtx, "SELECT '08:30:00.25'::TIME, ?::TIMETZ, NULL::TIME",
+		TimeTZ{Time: Time{Hour: 21}, Offset: -5 * 3600}).Scan(&opens, &closes, &empty)
+	assertNoErr(t, err)
+	assertEqual(t, opens, Time{Hour: 8, Minute: 30, Microsecond: 250000})
+	assertEqual(t, closes, TimeTZ{Time: Time{Hour: 21}, Offset: -5 * 3600})
+	assertEqual(t, empty.Valid, false)
+
+	var same bool
+	err = connDB.QueryRowContext(ctx, "SELECT ?::TIME = '08:30:00.25'::TIME", opens).Scan(&same)
+	assertNoErr(t, err)
+	assertEqual(t, same, true)
+}
+
+type testCelsius float64
+
+func TestTypeRegistry(t *testing.T) {
+	types := NewTypeRegistry()
+	assertNoErr(t, types.RegisterTypeDecoder("uuid", func(value []byte) (driver.Value, error) {
+		return strings.ToUpper(string(value)), nil
+	}, reflect.TypeOf("")))
+	assertNoErr(t, types.RegisterTypeEncoder(reflect.TypeOf(testCelsius(0)), func(value interface{}) (driver.Value, error) {
+		return float64(value.(testCelsius))*9/5 + 32, nil
+	}))
+
+	cfg, err := ParseDSN(myDBConnectString)
+	assertNoErr(t, err)
+	cfg.Types = types
+	connector, err := NewConnector(*cfg)
+	assertNoErr(t, err)
+	connDB := sql.OpenDB(connector)
+	defer closeConnection(t, connDB)
+
+	var (
+		id         string
+		fahrenheit float64
+	)
+	err = connDB.QueryRowContext(ctx, "SELECT '123e4567-e89b-12d3-a456-426614174000'::UUID, ?::FLOAT", testCelsius(100)).Scan(&id, &fahrenheit)
+	assertNoErr(t, err)
+	assertEqual(t, id, "123E4567-E89B-12D3-A456-426614174000")
+	assertEqual(t, fahrenheit, 212.0)
+}
+
+func TestNoticeHandler(t *testing.T) {
+	var fromConfig []*Notice
+	cfg, err := ParseDSN(myDBConnectString)
+	assertNoErr(t, err)
+	cfg.NoticeHandler = func(n *Notice) { fromConfig = append(fromConfig, n) }
+	connector, err := NewConnector(*cfg)
+	assertNoErr(t, err)
+	connDB := sql.OpenDB(connector)
+	defer closeConnection(t, connDB)
+
+	_, err = connDB.ExecContext(ctx, `
+		CREATE OR REPLACE PROCEDURE test_notice_handler_proc(a INT)
+		LANGUAGE PLvSQL AS $$
+		BEGIN
+			RAISE NOTICE 'first notice: %', a;
+			RAISE NOTICE 'second notice: %', a USING HINT = 'a hint';
+		END;
+		$$`)
+	assertNoErr(t, err)
+	defer connDB.ExecContext(ctx, "DROP PROCEDURE IF EXISTS test_notice_handler_proc(INT)")
+
+	_, err = connDB.ExecContext(ctx, "CALL test_notice_handler_proc(10)")
+	assertNoErr(t, err)
+	assertEqual(t, len(fromConfig), 2)
+	assertEqual(t, fromConfig[0].Message, "first notice: 10")
+	assertEqual(t, fromConfig[1].Message, "second notice: 10")
+	assertEqual(t, fromConfig[1].Hint, "a hint")
+
+	// A handler on the VerticaContext takes the place of the connector's.
+	var fromContext []*Notice
+	vCtx := NewVerticaContext(ctx)
+	assertNoErr(t, vCtx.SetNoticeHandler(func(n *Notice) { fromContext = append(fromContext, n) }))
+	_, err = connDB.ExecContext(vCtx, "CALL test_notice_handler_proc(20)")
+	assertNoErr(t, err)
+	assertEqual(t, len(fromContext), 2)
+	assertEqual(t, len(fromConfig), 2)
+
+	// The result keeps the notices of its own statement.
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+	rawErr := conn.Raw(func(driverConn interface{}) error {
+		stmt, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx, "CALL test_notice_handler_proc(30)")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		res, err := stmt.(driver.StmtExecContext).ExecContext(ctx, nil)
+		if err != nil {
+			return err
+		}
+		notices := res.(Result).Notices()
+		assertEqual(t, len(notices), 2)
+		assertEqual(t, notices[0].Message, "first notice: 30")
+		return nil
+	})
+	assertNoErr(t, rawErr)
+}
+
+func TestConnInterface(t *testing.T) {
+	connDB := openConnection(t, "test_basic_exec_pre")
+	defer closeConnection(t, connDB, "test_basic_exec_post")
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	var vConn Conn
+	assertNoErr(t, conn.Raw(func(driverConn interface{}) error {
+		vConn = driverConn.(Conn)
+		return nil
+	}))
+
+	if vConn.BackendPID() == 0 {
+		t.Error("expected a backend PID")
+	}
+	if vConn.ServerVersion() == "" {
+		t.Errorf("expected a server version in %v", vConn.ServerParameters())
+	}
+	assertEqual(t, vConn.TransactionStatus(), TransactionIdle)
+
+	assertNoErr(t, vConn.SetClientLabel("vertigo-conn-test"))
+	assertEqual(t, vConn.SessionLabel(), "vertigo-conn-test")
+	var label string
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT GET_CLIENT_LABEL()").Scan(&label))
+	assertEqual(t, label, "vertigo-conn-test")
+
+	// Without autocommit the insert leaves a transaction open until it is rolled back.
+	assertNoErr(t, vConn.SetAutocommit(false))
+	_, err = conn.ExecContext(ctx, "INSERT INTO MyTable VALUES (1, 'a')")
+	assertNoErr(t, err)
+	assertEqual(t, vConn.TransactionStatus(), TransactionActive)
+	_, err = conn.ExecContext(ctx, "ROLLBACK")
+	assertNoErr(t, err)
+	assertEqual(t, vConn.TransactionStatus(), TransactionIdle)
+	assertNoErr(t, vConn.SetAutocommit(true))
+
+	var count int
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM MyTable").Scan(&count))
+	assertEqual(t, count, 0)
+}
+
+func TestErrorClasses(t *testing.T) {
+	connDB := openConnection(t, "test_exec_batch_pre")
+	defer closeConnection(t, connDB, "test_exec_batch_post")
+
+	_, err := connDB.ExecContext(ctx, "INSERT INTO batch_test VALUES (1, 'a')")
+	assertNoErr(t, err)
+	_, err = connDB.ExecContext(ctx, "INSERT INTO batch_test VALUES (1, 'b')")
+	if !IsUniqueViolation(err) || !errors.Is(err, ErrUniqueViolation) {
+		t.Errorf("expected a unique violation, got %v", err)
+	}
+	if IsRetryable(err) {
+		t.Errorf("did not expect %v to be retryable", err)
+	}
+
+	_, err = connDB.QueryContext(ctx, "SELECT * FROM missing_error_class_table")
+	if !errors.Is(err, ErrUndefinedTable) {
+		t.Errorf("expected an undefined table, got %v", err)
+	}
+
+	_, err = connDB.QueryContext(ctx, "SELEC 1")
+	if !errors.Is(err, ErrSyntax) {
+		t.Errorf("expected a syntax error, got %v", err)
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	query := "SELECT @first AS a,\n       2 AS b\nFORM dual"
+	check := func(connDB *sql.DB) {
+		_, err := connDB.QueryContext(ctx, query, sql.Named("first", "a value longer than the parameter"))
+		var vErr *VError
+		if !errors.As(err, &vErr) {
+			t.Fatalf("expected a *VError, got %v", err)
+		}
+		assertEqual(t, vErr.Statement, query)
+		assertEqual(t, vErr.StatementLine, 3)
+		assertEqual(t, vErr.StatementColumn, 1)
+		if !strings.Contains(vErr.Verbose(), "LINE 3: FORM dual\n        ^") {
+			t.Errorf("unexpected excerpt in %q", vErr.Verbose())
+		}
+	}
+
+	connDB := openConnection(t)
+	defer closeConnection(t, connDB)
+	check(connDB)
+
+	simpleConnStr := strings.Replace(myDBConnectString, "use_prepared_statements=1", "use_prepared_statements=0", 1)
+	simpleDB, err := sql.Open("vertica", simpleConnStr)
+	assertNoErr(t, err)
+	defer closeConnection(t, simpleDB)
+	check(simpleDB)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	cfg, err := ParseDSN(myDBConnectString)
+	assertNoErr(t, err)
+	cfg.RetryPolicy = &RetryPolicy{MaxAttempts: 3, Backoff: ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond)}
+	connector, err := NewConnector(*cfg)
+	assertNoErr(t, err)
+	connDB := sql.OpenDB(connector)
+	defer closeConnection(t, connDB)
+
+	conn, err := connDB.Conn(ctx)
+	assertNoErr(t, err)
+	defer conn.Close()
+
+	var sessionID string
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT session_id FROM v_monitor.current_session").Scan(&sessionID))
+	prepared, err := conn.PrepareContext(ctx, "SELECT ? + 1 FROM dual")
+	assertNoErr(t, err)
+	defer prepared.Close()
+
+	// Closing the session from another connection makes the next statement
+	// fail, and the retry opens a new session to run it in.
+	otherDB := openConnection(t)
+	defer closeConnection(t, otherDB)
+	_, err = otherDB.ExecContext(ctx, "SELECT CLOSE_SESSION(?)", sessionID)
+	assertNoErr(t, err)
+
+	var value int
+	assertNoErr(t, prepared.QueryRowContext(ctx, 1).Scan(&value))
+	assertEqual(t, value, 2)
+
+	var newSessionID string
+	assertNoErr(t, conn.QueryRowContext(ctx, "SELECT session_id FROM v_monitor.current_session").Scan(&newSessionID))
+	if newSessionID == sessionID {
+		t.Errorf("expected a new session, still in %s", sessionID)
+	}
+
+	// Without SetIdempotent a statement that is not a SELECT is not run again.
+	_, err = otherDB.ExecContext(ctx, "SELECT CLOSE_SESSION(?)", newSessionID)
+	assertNoErr(t, err)
+	_, err = conn.ExecContext(ctx, "SET SESSION AUTOCOMMIT TO on")
+	if !IsConnectionFailure(err) {
+		t.Errorf("expected the lost session to be reported, got %v", err)
+	}
+}
@@ -34,7 +34,12 @@ package vertigo
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
 
+	"github.com/vertica/vertica-sql-go/common"
 	"github.com/vertica/vertica-sql-go/msgs"
 )
 
@@ -53,12 +58,101 @@ type VError struct {
 	File             string
 	Line             string
 	ErrorCode        string
+
+	// Statement is the SQL the caller submitted when the server reported a
+	// Position in it. StatementLine and StatementColumn locate Position in
+	// Statement, starting at 1.
+	Statement       string
+	StatementLine   int
+	StatementColumn int
 }
 
 func (ve *VError) Error() string {
 	return fmt.Sprintf("%s %s: [%s] %s", ve.Severity, ve.ErrorCode, ve.SQLState, ve.Message)
 }
 
+// Verbose returns the error followed by an excerpt of the statement with a
+// caret under the position of the error, and the error's detail, hint and
+// context, each on a line of its own.
+func (ve *VError) Verbose() string {
+	var b strings.Builder
+	b.WriteString(ve.Error())
+	if ve.StatementLine > 0 {
+		lines := strings.Split(ve.Statement, "\n")
+		if ve.StatementLine <= len(lines) {
+			line := strings.TrimRight(lines[ve.StatementLine-1], "\r")
+			prefix := fmt.Sprintf("LINE %d: ", ve.StatementLine)
+			fmt.Fprintf(&b, "\n%s%s\n%s", prefix, line, strings.Repeat(" ", len(prefix)))
+			// Keep tabs so the caret lines up with the excerpt.
+			column := 1
+			for _, r := range line {
+				if column >= ve.StatementColumn {
+					break
+				}
+				if r == '\t' {
+					b.WriteRune('\t')
+				} else {
+					b.WriteRune(' ')
+				}
+				column++
+			}
+			b.WriteRune('^')
+		}
+	}
+	for _, field := range []struct{ name, value string }{
+		{"DETAIL", ve.Detail},
+		{"HINT", ve.Hint},
+		{"WHERE", ve.Where},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&b, "\n%s: %s", field.name, field.value)
+		}
+	}
+	return b.String()
+}
+
+// Format prints the error as Verbose for the %+v verb and as Error otherwise.
+func (ve *VError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprint(f, ve.Verbose())
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", ve.Error())
+	default:
+		fmt.Fprint(f, ve.Error())
+	}
+}
+
+// locate sets the statement and the line and column of the error's Position,
+// given the SQL the caller submitted and where each offset of the SQL sent to
+// the server is found in it.
+func (ve *VError) locate(statement, sent string, toStatement func(int) int) {
+	position, err := strconv.Atoi(ve.Position)
+	if err != nil || position < 1 || sent == "" {
+		return
+	}
+
+	// Position counts characters from 1.
+	offset := len(sent)
+	chars := 1
+	for idx := range sent {
+		if chars == position {
+			offset = idx
+			break
+		}
+		chars++
+	}
+	offset = toStatement(offset)
+	if offset < 0 || offset > len(statement) {
+		return
+	}
+
+	ve.Statement = statement
+	ve.StatementLine = strings.Count(statement[:offset], "\n") + 1
+	lineStart := strings.LastIndex(statement[:offset], "\n") + 1
+	ve.StatementColumn = utf8.RuneCountInString(statement[lineStart:offset]) + 1
+}
+
 // Convert a wire protocol error message to a *VError
 func errorMsgToVError(m *msgs.BEErrorMsg) *VError {
 	return &VError{
@@ -77,3 +171,84 @@ func errorMsgToVError(m *msgs.BEErrorMsg) *VError {
 		ErrorCode:        m.ErrorCode,
 	}
 }
+
+// StatementError is a statement of a string of several statements that failed.
+type StatementError struct {
+	Index     int     // zero-based position of the statement in the string
+	Statement string  // the SQL of the statement
+	Err       *VError // the reason the statement failed
+}
+
+func (se *StatementError) Error() string {
+	return fmt.Sprintf("statement %d: %v", se.Index, se.Err)
+}
+
+// Unwrap returns the reason the statement failed.
+func (se *StatementError) Unwrap() error {
+	return se.Err
+}
+
+// ScriptError is returned for a string of several statements run with
+// VerticaContext.SetContinueOnError when any of them failed. An Exec returns
+// the outcome of the statements that succeeded along with it, which is only
+// reachable through sql.Conn.Raw, see Result.Statements. A Query returns no
+// rows with it.
+type ScriptError struct {
+	Errors []*StatementError // the statements that failed, in order
+}
+
+func (se *ScriptError) Error() string {
+	failures := make([]string, len(se.Errors))
+	for i, err := range se.Errors {
+		failures[i] = err.Error()
+	}
+	return fmt.Sprintf("%d statement(s) failed: %s", len(se.Errors), strings.Join(failures, "; "))
+}
+
+// Unwrap returns the first statement's error, so errors.As finds its *VError.
+func (se *ScriptError) Unwrap() error {
+	if len(se.Errors) == 0 {
+		return nil
+	}
+	return se.Errors[0]
+}
+
+// DecodeError is returned by rows.Next when a value sent by the server cannot be
+// converted into its Go value. It stops the iteration unless the query was run
+// with lenient decoding, see VerticaContext.SetLenientDecoding.
+type DecodeError struct {
+	Column     int    // zero-based index of the column in the result
+	ColumnName string // name of the column in the result
+	TypeOID    uint32 // type of the column, one of the common.ColType constants
+	Raw        []byte // the value as sent by the server
+	Err        error  // the reason the value could not be decoded
+}
+
+func (de *DecodeError) Error() string {
+	return fmt.Sprintf("unable to decode column %d (%s) of type %s: %v",
+		de.Column, de.ColumnName, common.ColumnTypeString(de.TypeOID, -1), de.Err)
+}
+
+// Unwrap returns the reason the value could not be decoded.
+func (de *DecodeError) Unwrap() error {
+	return de.Err
+}
+
+// decodeErrorLog collects the decode errors of lenient queries. It is shared
+// by every result read with the same VerticaContext.
+type decodeErrorLog struct {
+	mu   sync.Mutex
+	errs []*DecodeError
+}
+
+func (l *decodeErrorLog) add(err *DecodeError) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err)
+}
+
+func (l *decodeErrorLog) list() []*DecodeError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*DecodeError(nil), l.errs...)
+}
@@ -0,0 +1,80 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestScriptError(t *testing.T) {
+	first := &VError{Severity: "ERROR", ErrorCode: "4566", SQLState: "42V01", Message: `Relation "missing" does not exist`}
+	second := &VError{Severity: "ERROR", ErrorCode: "2624", SQLState: "22012", Message: "Division by zero"}
+	err := error(&ScriptError{Errors: []*StatementError{
+		{Index: 1, Statement: "INSERT INTO missing VALUES (1)", Err: first},
+		{Index: 3, Statement: "SELECT 1/0", Err: second},
+	}})
+
+	want := `2 statement(s) failed: statement 1: ERROR 4566: [42V01] Relation "missing" does not exist; ` +
+		"statement 3: ERROR 2624: [22012] Division by zero"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	var vErr *VError
+	if !errors.As(err, &vErr) || vErr != first {
+		t.Errorf("errors.As found %v, want the first statement's error", vErr)
+	}
+	var stmtErr *StatementError
+	if !errors.As(err, &stmtErr) || stmtErr.Index != 1 {
+		t.Errorf("errors.As found %v, want statement 1", stmtErr)
+	}
+}
+
+func TestVErrorVerboseWithoutPosition(t *testing.T) {
+	vErr := &VError{Severity: "ERROR", ErrorCode: "6745", SQLState: "23505", Message: "Duplicate key values: 'id=1'",
+		Detail: "violates constraint 'public.t.C_PRIMARY'", Where: "COPY"}
+	want := "ERROR 6745: [23505] Duplicate key values: 'id=1'\n" +
+		"DETAIL: violates constraint 'public.t.C_PRIMARY'\n" +
+		"WHERE: COPY"
+	if got := vErr.Verbose(); got != want {
+		t.Errorf("Verbose() = %q, want %q", got, want)
+	}
+	if got := fmt.Sprintf("%+v", vErr); got != want {
+		t.Errorf("%%+v = %q, want %q", got, want)
+	}
+	if got := fmt.Sprintf("%s", vErr); got != vErr.Error() {
+		t.Errorf("%%s = %q, want %q", got, vErr.Error())
+	}
+}
@@ -0,0 +1,75 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+	"time"
+)
+
+// The server's 'infinity' and '-infinity' DATE, TIMESTAMP and TIMESTAMPTZ values
+// are returned as times near the limits of what a time.Time can hold, far
+// outside the range of the server's types, so they sort after and before every
+// value read from a column. Passing either one as an argument
+// sends the matching infinity, which lets open-ended ranges round-trip:
+//
+//	_, err := db.Exec("INSERT INTO prices VALUES (?, ?)", validFrom, vertigo.PositiveInfinity)
+//	...
+//	if validTo.Equal(vertigo.PositiveInfinity) { ... }
+var (
+	PositiveInfinity = time.Date(292277024626, time.December, 31, 23, 59, 59, 999999999, time.UTC)
+	NegativeInfinity = time.Date(-292277022399, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// infinityText returns the literal for t when it is one of the infinity
+// sentinels.
+func infinityText(t time.Time) (string, bool) {
+	switch {
+	case t.Equal(PositiveInfinity):
+		return "infinity", true
+	case t.Equal(NegativeInfinity):
+		return "-infinity", true
+	}
+	return "", false
+}
+
+// parseInfinity returns the sentinel for an infinity value sent by the server.
+func parseInfinity(text string) (time.Time, bool) {
+	switch {
+	case strings.HasPrefix(text, "-infinity"):
+		return NegativeInfinity, true
+	case strings.HasPrefix(text, "infinity"), strings.HasPrefix(text, "+infinity"):
+		return PositiveInfinity, true
+	}
+	return time.Time{}, false
+}
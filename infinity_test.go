@@ -0,0 +1,94 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestInfinityColumns(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "d", DataTypeOID: common.ColTypeDate, DataTypeName: "date"},
+		{FieldName: "ts", DataTypeOID: common.ColTypeTimestamp, DataTypeName: "timestamp"},
+		{FieldName: "tstz", DataTypeOID: common.ColTypeTimestampTZ, DataTypeName: "timestamptz"},
+	}}
+	result := make([]driver.Value, 3)
+
+	rows := newTestRows(t, desc, []string{"infinity", "-infinity", "infinity"})
+	rows.location = time.FixedZone("", -5*3600)
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result[0] != PositiveInfinity || result[1] != NegativeInfinity || result[2] != PositiveInfinity {
+		t.Errorf("unexpected values %v", result)
+	}
+
+	for _, col := range desc.Columns {
+		for v, expected := range map[int64]time.Time{math.MaxInt64: PositiveInfinity, math.MinInt64: NegativeInfinity} {
+			colVal := make([]byte, 8)
+			binary.BigEndian.PutUint64(colVal, uint64(v))
+			got, err := rows.decodeBinary(col, colVal)
+			if err != nil || got != expected {
+				t.Errorf("%s: expected %v, got %v, %v", col.DataTypeName, expected, got, err)
+			}
+		}
+	}
+}
+
+func TestInfinityArguments(t *testing.T) {
+	args := []driver.NamedValue{{Ordinal: 1, Value: PositiveInfinity}, {Ordinal: 2, Value: NegativeInfinity}}
+	encoded, err := encodeBindArgs(args, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded[0].Value != "infinity" || encoded[1].Value != "-infinity" {
+		t.Errorf("unexpected encoded arguments %v", encoded)
+	}
+
+	literal, err := encodeArrayLiteral(Array{PositiveInfinity, NegativeInfinity}, common.ParseComplexType(1510, ""))
+	if err != nil || literal != `["infinity","-infinity"]` {
+		t.Errorf("unexpected array literal %s, %v", literal, err)
+	}
+
+	if !PositiveInfinity.After(time.Date(294277, 1, 10, 0, 0, 0, 0, time.UTC)) ||
+		!NegativeInfinity.Before(time.Date(-4713, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the sentinels to sort outside the range of the server's types")
+	}
+}
@@ -0,0 +1,346 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+)
+
+const (
+	microsPerSecond = int64(1000000)
+	microsPerMinute = 60 * microsPerSecond
+	microsPerHour   = 60 * microsPerMinute
+	microsPerDay    = 24 * microsPerHour
+)
+
+// Interval is a Vertica INTERVAL value. Year-month intervals only use Months
+// and day-time intervals only use Days and Microseconds, which holds the
+// hours, minutes and seconds. With typed_intervals enabled, INTERVAL columns
+// are returned as Interval rather than string. An Interval or a time.Duration
+// can be used as a query argument.
+type Interval struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+}
+
+// IntervalFromDuration returns the day-time interval for d, truncated to whole
+// microseconds.
+func IntervalFromDuration(d time.Duration) Interval {
+	micros := d.Microseconds()
+	return Interval{
+		Days:         int32(micros / microsPerDay),
+		Microseconds: micros % microsPerDay,
+	}
+}
+
+// Duration returns a day-time interval as a time.Duration. It fails for
+// intervals with months, which have no fixed length, and for intervals too
+// long to fit.
+func (iv Interval) Duration() (time.Duration, error) {
+	if iv.Months != 0 {
+		return 0, fmt.Errorf("interval %s has months and no fixed duration", iv)
+	}
+	const maxMicros = math.MaxInt64 / int64(time.Microsecond)
+	days := int64(iv.Days)
+	if days > (maxMicros-iv.Microseconds)/microsPerDay || days < (-maxMicros-iv.Microseconds)/microsPerDay {
+		return 0, fmt.Errorf("interval %s is out of range for time.Duration", iv)
+	}
+	return time.Duration(days*microsPerDay+iv.Microseconds) * time.Microsecond, nil
+}
+
+// String returns the interval as a literal the server accepts, such as
+// "1 year 2 months" or "3 days 4 hours 5 minutes 6.5 seconds ago".
+func (iv Interval) String() string {
+	months, days, micros := int64(iv.Months), int64(iv.Days), iv.Microseconds
+
+	// A wholly negative interval reads best with every unit positive and "ago".
+	ago := months <= 0 && days <= 0 && micros <= 0 && (months|days|micros) != 0
+	if ago {
+		months, days, micros = -months, -days, -micros
+	}
+
+	var parts []string
+	addUnit := func(n int64, unit string) {
+		if n == 0 {
+			return
+		}
+		if n == 1 || n == -1 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, unit))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d %ss", n, unit))
+		}
+	}
+	addUnit(months/12, "year")
+	addUnit(months%12, "month")
+	addUnit(days, "day")
+	addUnit(micros/microsPerHour, "hour")
+	addUnit(micros%microsPerHour/microsPerMinute, "minute")
+	if secs := micros % microsPerMinute; secs != 0 {
+		text := strconv.FormatFloat(float64(secs)/float64(microsPerSecond), 'f', -1, 64)
+		if secs == microsPerSecond || secs == -microsPerSecond {
+			parts = append(parts, text+" second")
+		} else {
+			parts = append(parts, text+" seconds")
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+	if ago {
+		parts = append(parts, "ago")
+	}
+	return strings.Join(parts, " ")
+}
+
+// encode returns the interval as text for a value of the interval type typeOID,
+// which is 0 if unknown, together with the range to qualify a literal with:
+// YEAR TO MONTH for months, and none for the default DAY TO SECOND. It fails
+// for an interval that mixes months with days or seconds, as no Vertica
+// interval holds both, and for one that does not fit typeOID.
+func (iv Interval) encode(typeOID uint32) (text, intervalRange string, err error) {
+	dayTime := iv.Days != 0 || iv.Microseconds != 0
+	switch {
+	case iv.Months != 0 && dayTime:
+		return "", "", fmt.Errorf("interval %s mixes months with days or seconds, which no Vertica interval holds", iv)
+	case iv.Months != 0 && typeOID == common.ColTypeInterval:
+		return "", "", fmt.Errorf("interval %s has months and cannot be a day-time interval", iv)
+	case dayTime && typeOID == common.ColTypeIntervalYM:
+		return "", "", fmt.Errorf("interval %s has days or seconds and cannot be a year-month interval", iv)
+	case iv.Months != 0:
+		return iv.String(), "YEAR TO MONTH", nil
+	}
+	return iv.String(), "", nil
+}
+
+// Value returns the interval literal.
+// Interface: driver.Valuer
+func (iv Interval) Value() (driver.Value, error) {
+	return iv.String(), nil
+}
+
+// Scan reads an Interval, or the text the server prints for an interval. Text
+// without a type is read as DAY TO SECOND, unless it has the years-months form
+// "1-2". Use NullInterval for nullable columns.
+// Interface: sql.Scanner
+func (iv *Interval) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case Interval:
+		*iv = v
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Interval, use NullInterval instead")
+	default:
+		return fmt.Errorf("cannot scan %T into Interval", src)
+	}
+
+	intervalRange := "DAY TO SECOND"
+	if t := strings.TrimPrefix(strings.TrimSpace(text), "-"); strings.Contains(t, "-") && !strings.ContainsAny(t, " :") {
+		intervalRange = "YEAR TO MONTH"
+	}
+	val, err := parseInterval(text, intervalRange)
+	if err != nil {
+		return err
+	}
+	*iv = val
+	return nil
+}
+
+// NullInterval is an Interval that may be NULL.
+type NullInterval struct {
+	Interval Interval
+	Valid    bool // Valid is true if Interval is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullInterval) Scan(src interface{}) error {
+	if src == nil {
+		n.Interval, n.Valid = Interval{}, false
+		return nil
+	}
+	if err := n.Interval.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullInterval) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Interval.Value()
+}
+
+// parseInterval parses the text the server prints for an interval of the given
+// range, e.g. "1-2" for YEAR TO MONTH or "-1 02:03:04.5" for DAY TO SECOND. A
+// leading minus sign applies to the whole value.
+func parseInterval(text, intervalRange string) (Interval, error) {
+	s := strings.TrimSpace(text)
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	var iv Interval
+	var err error
+	if strings.HasPrefix(intervalRange, "YEAR") || strings.HasPrefix(intervalRange, "MONTH") {
+		iv.Months, err = parseYearMonth(s, intervalRange)
+	} else {
+		iv.Days, iv.Microseconds, err = parseDayTime(s, intervalRange)
+	}
+	if err != nil {
+		return Interval{}, fmt.Errorf("invalid %s interval %q: %v", intervalRange, text, err)
+	}
+
+	if negative {
+		iv.Months, iv.Days, iv.Microseconds = -iv.Months, -iv.Days, -iv.Microseconds
+	}
+	return iv, nil
+}
+
+func parseYearMonth(s, intervalRange string) (int32, error) {
+	var months int64
+	if years, rest, found := strings.Cut(s, "-"); found {
+		y, err := parseIntervalField(years)
+		if err != nil {
+			return 0, err
+		}
+		m, err := parseIntervalField(rest)
+		if err != nil {
+			return 0, err
+		}
+		months = y*12 + m
+	} else {
+		n, err := parseIntervalField(s)
+		if err != nil {
+			return 0, err
+		}
+		months = n
+		if intervalRange != "MONTH" {
+			months *= 12
+		}
+	}
+	if months > math.MaxInt32 {
+		return 0, fmt.Errorf("out of range")
+	}
+	return int32(months), nil
+}
+
+// parseDayTime parses "[days ]h[:m[:s[.f]]]", where the first time field is
+// the leading unit of the range: hours for DAY and HOUR ranges, minutes for
+// MINUTE ranges and seconds for SECOND.
+func parseDayTime(s, intervalRange string) (int32, int64, error) {
+	var days int64
+	var err error
+
+	timePart := s
+	if dayPart, rest, found := strings.Cut(s, " "); found {
+		if days, err = parseIntervalField(dayPart); err != nil {
+			return 0, 0, err
+		}
+		timePart = strings.TrimSpace(rest)
+	} else if strings.HasPrefix(intervalRange, "DAY") && !strings.Contains(s, ":") {
+		if days, err = parseIntervalField(s); err != nil {
+			return 0, 0, err
+		}
+		timePart = ""
+	}
+	if days > math.MaxInt32 {
+		return 0, 0, fmt.Errorf("out of range")
+	}
+
+	var micros int64
+	if timePart != "" {
+		units := []int64{microsPerHour, microsPerMinute, microsPerSecond}
+		switch {
+		case strings.HasPrefix(intervalRange, "MINUTE"):
+			units = units[1:]
+		case strings.HasPrefix(intervalRange, "SECOND"):
+			units = units[2:]
+		}
+		fields := strings.Split(timePart, ":")
+		if len(fields) > len(units) {
+			return 0, 0, fmt.Errorf("too many time fields")
+		}
+		for idx, field := range fields {
+			unit := units[idx]
+			whole, frac, hasFrac := strings.Cut(field, ".")
+			if hasFrac && unit != microsPerSecond {
+				return 0, 0, fmt.Errorf("fractional %q", field)
+			}
+			n, err := parseIntervalField(whole)
+			if err != nil {
+				return 0, 0, err
+			}
+			micros += n * unit
+			if hasFrac {
+				f, err := parseFraction(frac)
+				if err != nil {
+					return 0, 0, err
+				}
+				micros += f
+			}
+		}
+	}
+	return int32(days), micros, nil
+}
+
+func parseIntervalField(s string) (int64, error) {
+	if s == "" || strings.ContainsAny(s, "+-") {
+		return 0, fmt.Errorf("bad field %q", s)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseFraction returns the microseconds in the digits after a decimal point.
+func parseFraction(s string) (int64, error) {
+	if len(s) > 6 {
+		s = s[:6]
+	}
+	return parseIntervalField(s + strings.Repeat("0", 6-len(s)))
+}
@@ -0,0 +1,205 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestParseInterval(t *testing.T) {
+	const (
+		hour   = microsPerHour
+		minute = microsPerMinute
+		second = microsPerSecond
+	)
+	testCases := []struct {
+		text     string
+		rng      string
+		expected Interval
+	}{
+		{text: "1 02:03:04", rng: "DAY TO SECOND", expected: Interval{Days: 1, Microseconds: 2*hour + 3*minute + 4*second}},
+		{text: "-1 02:03:04.5", rng: "DAY TO SECOND", expected: Interval{Days: -1, Microseconds: -(2*hour + 3*minute + 4*second + 500000)}},
+		{text: "00:00:00.000001", rng: "DAY TO SECOND", expected: Interval{Microseconds: 1}},
+		{text: "0 00:00:01.1234567", rng: "DAY TO SECOND", expected: Interval{Microseconds: second + 123456}},
+		{text: "3", rng: "DAY", expected: Interval{Days: 3}},
+		{text: "1 02", rng: "DAY TO HOUR", expected: Interval{Days: 1, Microseconds: 2 * hour}},
+		{text: "1 02:03", rng: "DAY TO MINUTE", expected: Interval{Days: 1, Microseconds: 2*hour + 3*minute}},
+		{text: "26", rng: "HOUR", expected: Interval{Microseconds: 26 * hour}},
+		{text: "26:03", rng: "HOUR TO MINUTE", expected: Interval{Microseconds: 26*hour + 3*minute}},
+		{text: "26:03:04.25", rng: "HOUR TO SECOND", expected: Interval{Microseconds: 26*hour + 3*minute + 4*second + 250000}},
+		{text: "-90", rng: "MINUTE", expected: Interval{Microseconds: -90 * minute}},
+		{text: "90:30", rng: "MINUTE TO SECOND", expected: Interval{Microseconds: 90*minute + 30*second}},
+		{text: "12.5", rng: "SECOND", expected: Interval{Microseconds: 12*second + 500000}},
+		{text: "1-2", rng: "YEAR TO MONTH", expected: Interval{Months: 14}},
+		{text: "-1-2", rng: "YEAR TO MONTH", expected: Interval{Months: -14}},
+		{text: "3", rng: "YEAR", expected: Interval{Months: 36}},
+		{text: "14", rng: "MONTH", expected: Interval{Months: 14}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.rng+" "+tc.text, func(t *testing.T) {
+			result, err := parseInterval(tc.text, tc.rng)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "1 x", "1 02:03:04:05", "02.5:03", "1--2", "1 -02:03"} {
+		if _, err := parseInterval(bad, "DAY TO SECOND"); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+	if _, err := parseInterval("1-x", "YEAR TO MONTH"); err == nil {
+		t.Errorf("expected a bad year-month interval to be rejected")
+	}
+}
+
+func TestIntervalDuration(t *testing.T) {
+	iv := Interval{Days: -1, Microseconds: -(2*microsPerHour + 500000)}
+	d, err := iv.Duration()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := -(26*time.Hour + 500*time.Millisecond); d != expected {
+		t.Errorf("expected %v, got %v", expected, d)
+	}
+	if back := IntervalFromDuration(d); back != iv {
+		t.Errorf("expected %+v, got %+v", iv, back)
+	}
+	if IntervalFromDuration(1500*time.Nanosecond) != (Interval{Microseconds: 1}) {
+		t.Errorf("expected nanoseconds to be truncated")
+	}
+
+	if _, err := (Interval{Months: 1}).Duration(); err == nil {
+		t.Errorf("expected an interval with months to have no duration")
+	}
+	if _, err := (Interval{Days: 200000}).Duration(); err == nil {
+		t.Errorf("expected an interval longer than 292 years to overflow")
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	testCases := []struct {
+		value    Interval
+		expected string
+	}{
+		{value: Interval{}, expected: "0 seconds"},
+		{value: Interval{Months: 14}, expected: "1 year 2 months"},
+		{value: Interval{Days: 3, Microseconds: 4*microsPerHour + 5*microsPerMinute + 6500000}, expected: "3 days 4 hours 5 minutes 6.5 seconds"},
+		{value: Interval{Days: 1, Microseconds: microsPerSecond}, expected: "1 day 1 second"},
+		{value: Interval{Months: -1, Microseconds: -1}, expected: "1 month 0.000001 seconds ago"},
+		{value: Interval{Months: 1, Days: -2}, expected: "1 month -2 days"},
+	}
+	for _, tc := range testCases {
+		if result := tc.value.String(); result != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, result)
+		}
+	}
+}
+
+func TestIntervalScan(t *testing.T) {
+	var iv Interval
+	if err := iv.Scan("1 02:00:00"); err != nil || iv != (Interval{Days: 1, Microseconds: 2 * microsPerHour}) {
+		t.Errorf("unexpected scan result %+v, %v", iv, err)
+	}
+	if err := iv.Scan([]byte("-2-6")); err != nil || iv != (Interval{Months: -30}) {
+		t.Errorf("unexpected scan result %+v, %v", iv, err)
+	}
+	if err := iv.Scan(Interval{Days: 5}); err != nil || iv != (Interval{Days: 5}) {
+		t.Errorf("unexpected scan result %+v, %v", iv, err)
+	}
+	if err := iv.Scan(nil); err == nil {
+		t.Errorf("expected NULL to be rejected")
+	}
+	if err := iv.Scan(int64(1)); err == nil {
+		t.Errorf("expected an int64 to be rejected")
+	}
+
+	var n NullInterval
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("unexpected scan result %+v, %v", n, err)
+	}
+	if err := n.Scan("00:01:00"); err != nil || !n.Valid || n.Interval != (Interval{Microseconds: microsPerMinute}) {
+		t.Errorf("unexpected scan result %+v, %v", n, err)
+	}
+	if v, err := n.Value(); v != "1 minute" || err != nil {
+		t.Errorf("expected 1 minute, got %v, %v", v, err)
+	}
+}
+
+func TestTypedIntervalColumns(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "ds", DataTypeOID: common.ColTypeInterval, DataTypeName: "interval", DataTypeMod: -1},
+		{FieldName: "ym", DataTypeOID: common.ColTypeIntervalYM, DataTypeName: "interval year to month", DataTypeMod: -1},
+		{FieldName: "m", DataTypeOID: common.ColTypeInterval, DataTypeName: "interval minute", DataTypeMod: 1 << 27},
+	}}
+	result := make([]driver.Value, 3)
+
+	rows := newTestRows(t, desc, []string{"1 02:03:04", "1-2", "90"})
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result[0] != "1 02:03:04" || result[1] != "1-2" || result[2] != "90" {
+		t.Errorf("expected intervals to default to strings, got %v", result)
+	}
+	if scanType := rows.ColumnTypeScanType(0); scanType != reflect.TypeOf(sql.NullString{}) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+
+	rows = newTestRows(t, desc, []string{"1 02:03:04", "1-2", "90"})
+	rows.typedIntervals = true
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []driver.Value{
+		Interval{Days: 1, Microseconds: 2*microsPerHour + 3*microsPerMinute + 4*microsPerSecond},
+		Interval{Months: 14},
+		Interval{Microseconds: 90 * microsPerMinute},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	if scanType := rows.ColumnTypeScanType(1); scanType != reflect.TypeOf(NullInterval{}) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+}
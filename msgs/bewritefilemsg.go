@@ -32,8 +32,14 @@ package msgs
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-import "fmt"
+import (
+	"encoding/binary"
+	"fmt"
+)
 
+// BEWriteFileMsg asks the client to append Data to FileName, for COPY rejected
+// data and exceptions files that live on the client. An empty FileName means
+// Data holds the numbers of the rejected rows instead.
 type BEWriteFileMsg struct {
 	FileName string
 	Data     []byte
@@ -46,7 +52,18 @@ func (m *BEWriteFileMsg) CreateFromMsgBody(buf *msgBuffer) (BackEndMsg, error) {
 
 	res.FileName = buf.readString()
 
-	dataSize := buf.readUint32()
+	dataSize := int(buf.readUint32())
+
+	// Rejected row numbers are sent as a count of 8 byte values rather than a
+	// byte length.
+	if res.FileName == "" {
+		dataSize *= 8
+	}
+
+	if dataSize > buf.remainingBytes() {
+		return nil, fmt.Errorf("WriteFile: %d byte(s) announced but %d sent", dataSize, buf.remainingBytes())
+	}
+
 	res.Data = make([]byte, dataSize)
 
 	buf.readBytes(res.Data)
@@ -54,6 +71,15 @@ func (m *BEWriteFileMsg) CreateFromMsgBody(buf *msgBuffer) (BackEndMsg, error) {
 	return res, nil
 }
 
+// RejectedRows decodes the row numbers carried by a message with no FileName.
+func (m *BEWriteFileMsg) RejectedRows() []uint64 {
+	rowNums := make([]uint64, 0, len(m.Data)/8)
+	for data := m.Data; len(data) >= 8; data = data[8:] {
+		rowNums = append(rowNums, binary.BigEndian.Uint64(data))
+	}
+	return rowNums
+}
+
 func (m *BEWriteFileMsg) String() string {
 	return fmt.Sprintf("WriteFile: filename '%s', data: %d byte(s)", m.FileName, len(m.Data))
 }
@@ -0,0 +1,82 @@
+package msgs
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileMsg(t *testing.T) {
+	body := append([]byte("rejects.txt\x00\x00\x00\x00\x05"), []byte("a,b\nc")...)
+	msg, err := CreateBackEndMsg('O', body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wf := msg.(*BEWriteFileMsg)
+	if wf.FileName != "rejects.txt" || !bytes.Equal(wf.Data, []byte("a,b\nc")) {
+		t.Errorf("unexpected message %+v", wf)
+	}
+}
+
+func TestWriteFileMsgRejectedRows(t *testing.T) {
+	rowData := []byte{0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 1, 0}
+
+	// The length is the number of rows.
+	msg, err := CreateBackEndMsg('O', append([]byte{0, 0, 0, 0, 2}, rowData...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := msg.(*BEWriteFileMsg).RejectedRows()
+	if !reflect.DeepEqual(rows, []uint64{3, 256}) {
+		t.Errorf("unexpected rejected rows %v", rows)
+	}
+
+	// A count that understates the rows leaves bytes over, while a length in
+	// bytes or a count that overstates the rows announces more than was sent.
+	for _, tc := range []struct {
+		size byte
+		err  string
+	}{
+		{1, "8 byte(s) remaining"},
+		{3, "24 byte(s) announced but 16 sent"},
+		{16, "128 byte(s) announced but 16 sent"},
+	} {
+		_, err := CreateBackEndMsg('O', append([]byte{0, 0, 0, 0, tc.size}, rowData...))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("expected %q for a length of %d, got %v", tc.err, tc.size, err)
+		}
+	}
+}
@@ -45,6 +45,13 @@ type FEBindMsg struct {
 	Statement string
 	NamedArgs []driver.NamedValue
 	OIDTypes  []int32
+	// ParamFormats holds a format code per argument, 0 for text and 1 for
+	// binary. A binary argument must be a []byte holding the encoded value.
+	// When empty every argument is sent as text.
+	ParamFormats []uint16
+	// ResultFormats holds a format code per result column, 0 for text and 1
+	// for binary. When empty every column is sent as text.
+	ResultFormats []uint16
 }
 
 // Flatten docs
@@ -55,8 +62,10 @@ func (m *FEBindMsg) Flatten() ([]byte, byte) {
 	buf.appendString(m.Portal)
 	buf.appendString(m.Statement)
 
-	// no parameter format codes for now
-	buf.appendUint16(0)
+	buf.appendUint16(uint16(len(m.ParamFormats))) // none means all arguments in text format
+	for _, format := range m.ParamFormats {
+		buf.appendUint16(format)
+	}
 
 	// number of arguments
 	buf.appendUint16(uint16(len(m.NamedArgs)))
@@ -65,9 +74,16 @@ func (m *FEBindMsg) Flatten() ([]byte, byte) {
 		buf.appendUint32(uint32(oidType))
 	}
 
-	var strVal string
+	for idx, arg := range m.NamedArgs {
+		if m.isBinary(idx) {
+			b := arg.Value.([]byte)
+			buf.appendUint32(uint32(len(b)))
+			buf.appendBytes(b)
+			continue
+		}
 
-	for _, arg := range m.NamedArgs {
+		// Validate has ruled out any other type.
+		var strVal string
 		switch v := arg.Value.(type) {
 		case int64, float64:
 			strVal = fmt.Sprintf("%v", v)
@@ -90,19 +106,41 @@ func (m *FEBindMsg) Flatten() ([]byte, byte) {
 			buf.appendUint32(uint32(len(v)))
 			buf.appendBytes(v)
 			continue
-		default:
-			strVal = "??HELP??"
 		}
 
 		buf.appendUint32(uint32(len(strVal)))
 		buf.appendBytes([]byte(strVal))
 	}
 
-	buf.appendUint16(0) // all columns in default format
+	buf.appendUint16(uint16(len(m.ResultFormats))) // none means all columns in default format
+	for _, format := range m.ResultFormats {
+		buf.appendUint16(format)
+	}
 
 	return buf.bytes(), 'B'
 }
 
+// Validate reports an argument of a type Flatten cannot encode.
+func (m *FEBindMsg) Validate() error {
+	for idx, arg := range m.NamedArgs {
+		if m.isBinary(idx) {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case int64, float64, string, bool, nil, time.Time, []uint8:
+		default:
+			return fmt.Errorf("unsupported argument type %T for parameter %d", v, arg.Ordinal)
+		}
+	}
+	return nil
+}
+
+// isBinary reports whether argument idx is sent in the binary format.
+func (m *FEBindMsg) isBinary(idx int) bool {
+	_, ok := m.NamedArgs[idx].Value.([]byte)
+	return ok && idx < len(m.ParamFormats) && m.ParamFormats[idx] == 1
+}
+
 func (m *FEBindMsg) String() string {
 	return fmt.Sprintf(
 		"Bind: Portal='%s', Statement='%s', ArgC=%d",
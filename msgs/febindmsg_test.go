@@ -90,10 +90,10 @@ func TestFlatten(t *testing.T) {
 				NamedArgs: []driver.NamedValue{tc.value},
 				OIDTypes:  nil,
 			}
-			result, _ := msg.Flatten()
-			if bytes.Contains(result, []byte("??HELP??")) {
-				t.Error("fell into a bad place, got the panic message")
+			if err := msg.Validate(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
 			}
+			result, _ := msg.Flatten()
 			// Trim those first 6 identical bytes for simplicity
 			if !bytes.Equal(result[6:], tc.expected) {
 				t.Errorf("got %#v expected %#v for message", result, tc.expected)
@@ -101,3 +101,50 @@ func TestFlatten(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUnsupportedType(t *testing.T) {
+	msg := FEBindMsg{NamedArgs: []driver.NamedValue{
+		{Ordinal: 1, Value: int64(1)},
+		{Ordinal: 2, Value: uint32(2)},
+	}}
+	err := msg.Validate()
+	if err == nil || err.Error() != "unsupported argument type uint32 for parameter 2" {
+		t.Errorf("expected an unsupported type error, got %v", err)
+	}
+}
+
+func TestFlattenParamFormats(t *testing.T) {
+	msg := FEBindMsg{
+		NamedArgs: []driver.NamedValue{
+			{Ordinal: 1, Value: []byte{0x5c, 0x00}},
+			{Ordinal: 2, Value: []byte{0x5c}},
+		},
+		OIDTypes:     []int32{17, 17},
+		ParamFormats: []uint16{1, 0},
+	}
+	result, _ := msg.Flatten()
+	expected := []byte{
+		0x0, 0x0, // portal and statement
+		0x0, 0x2, 0x0, 0x1, 0x0, 0x0, // param formats
+		0x0, 0x2, // number of arguments
+		0x0, 0x0, 0x0, 0x11, 0x0, 0x0, 0x0, 0x11, // param types
+		0x0, 0x0, 0x0, 0x2, 0x5c, 0x0, // binary, sent as-is
+		0x0, 0x0, 0x0, 0x4, 0x5c, 0x31, 0x33, 0x34, // text, escaped
+		0x0, 0x0, // result formats
+	}
+	if !bytes.Equal(result, expected) {
+		t.Errorf("got %#v, expected %#v", result, expected)
+	}
+}
+
+func TestFlattenResultFormats(t *testing.T) {
+	msg := FEBindMsg{ResultFormats: []uint16{1, 0, 1}}
+	result, msgType := msg.Flatten()
+	if msgType != 'B' {
+		t.Errorf("unexpected message type %c", msgType)
+	}
+	expected := []byte{0x0, 0x3, 0x0, 0x1, 0x0, 0x0, 0x0, 0x1}
+	if !bytes.HasSuffix(result, expected) {
+		t.Errorf("got %#v, expected the result formats %#v at the end", result, expected)
+	}
+}
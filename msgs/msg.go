@@ -51,6 +51,13 @@ type FrontEndMsg interface {
 	String() string
 }
 
+// Validator is implemented by front end messages that can hold values they
+// cannot encode. Validate is called before Flatten, and nothing is sent if it
+// fails.
+type Validator interface {
+	Validate() error
+}
+
 // BackEndMsg is received from the database
 type BackEndMsg interface {
 	CreateFromMsgBody(*msgBuffer) (BackEndMsg, error)
@@ -0,0 +1,77 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "github.com/vertica/vertica-sql-go/msgs"
+
+// Notice is a message the Vertica server sent while a statement ran that is
+// not an error, such as the output of RAISE NOTICE in a stored procedure.
+type Notice struct {
+	InternalQuery    string
+	Severity         string
+	Message          string
+	SQLState         string
+	Detail           string
+	Hint             string
+	Position         string
+	Where            string
+	InternalPosition string
+	Routine          string
+	File             string
+	Line             string
+	ErrorCode        string
+}
+
+// NoticeHandler receives each notice the server sends. It is called on the
+// goroutine reading from the connection while the statement runs, so it must
+// not use the connection itself.
+type NoticeHandler func(*Notice)
+
+// Convert a wire protocol notice message to a *Notice
+func noticeMsgToNotice(m *msgs.BENoticeMsg) *Notice {
+	return &Notice{
+		InternalQuery:    m.InternalQuery,
+		Severity:         m.Severity,
+		Message:          m.Message,
+		SQLState:         m.SQLState,
+		Detail:           m.Detail,
+		Hint:             m.Hint,
+		Position:         m.Position,
+		Where:            m.Where,
+		InternalPosition: m.InternalPosition,
+		Routine:          m.Routine,
+		File:             m.File,
+		Line:             m.Line,
+		ErrorCode:        m.ErrorCode,
+	}
+}
@@ -0,0 +1,76 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestHandleNotice(t *testing.T) {
+	var fromConfig, fromContext []*Notice
+	conn := &connection{config: Config{NoticeHandler: func(n *Notice) { fromConfig = append(fromConfig, n) }}}
+
+	notice := noticeMsgToNotice(&msgs.BENoticeMsg{
+		Severity: "NOTICE", Message: "Value of a: 10", SQLState: "00000", Hint: "a hint", ErrorCode: "2005",
+	})
+	if notice.Message != "Value of a: 10" || notice.SQLState != "00000" || notice.Hint != "a hint" || notice.ErrorCode != "2005" {
+		t.Errorf("fields were not copied: %+v", notice)
+	}
+
+	conn.handleNotice(notice)
+	if len(fromConfig) != 1 || fromConfig[0] != notice {
+		t.Errorf("the config's handler did not get the notice: %v", fromConfig)
+	}
+
+	// A VerticaContext's handler takes the place of the config's.
+	vCtx := NewVerticaContext(ctx)
+	if err := vCtx.SetNoticeHandler(func(n *Notice) { fromContext = append(fromContext, n) }); err != nil {
+		t.Fatal(err)
+	}
+	conn.noticeHandler = noticeHandler(vCtx)
+	conn.handleNotice(&Notice{Message: "second"})
+	if len(fromContext) != 1 || len(fromConfig) != 1 {
+		t.Errorf("expected only the context's handler to be called, got %d and %d notices", len(fromContext), len(fromConfig))
+	}
+
+	// Every notice is kept for the running statement until it is taken.
+	notices := conn.takeNotices()
+	if len(notices) != 2 || notices[0] != notice || notices[1].Message != "second" {
+		t.Errorf("unexpected notices %v", notices)
+	}
+	if len(conn.takeNotices()) != 0 {
+		t.Error("takeNotices did not clear the notices")
+	}
+}
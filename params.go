@@ -0,0 +1,395 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// checkBindValue converts a bind argument into one of the values the driver
+// knows how to send: nil, int64, float64, bool, string, []byte, time.Time,
+// Decimal, Interval, Time, TimeTZ or an Array of those. Anything it cannot represent is an error rather
+// than a guess. Encoders registered in types, or globally when types is nil,
+// are applied first.
+func checkBindValue(value interface{}, types *TypeRegistry) (interface{}, error) {
+	if types == nil {
+		types = defaultTypeRegistry
+	}
+	return convertBindValue(value, true, types)
+}
+
+// convertBindValue converts value as described for checkBindValue. With a nil
+// types only the built-in rules apply.
+func convertBindValue(value interface{}, callValuer bool, types *TypeRegistry) (interface{}, error) {
+	if types != nil {
+		if encode := types.lookupEncoder(value); encode != nil {
+			encoded, err := encode(value)
+			if err != nil {
+				return nil, err
+			}
+			return convertBindValue(encoded, true, nil)
+		}
+	}
+
+	switch v := value.(type) {
+	case nil, int64, float64, bool, string, []byte, time.Time:
+		return v, nil
+	case Decimal:
+		if err := v.validate(); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case Interval, Time, TimeTZ:
+		return v, nil
+	case time.Duration:
+		return IntervalFromDuration(v), nil
+	case *big.Rat:
+		if v == nil {
+			return nil, nil
+		}
+		return NewDecimalFromRat(v)
+	case *big.Int:
+		if v == nil {
+			return nil, nil
+		}
+		return Decimal(v.String()), nil
+	case uint64:
+		// Values past the range of a Vertica INTEGER are sent as numerics so
+		// they can still land in a NUMERIC column without losing digits.
+		if v > math.MaxInt64 {
+			return Decimal(strconv.FormatUint(v, 10)), nil
+		}
+		return int64(v), nil
+	case json.RawMessage:
+		if v == nil {
+			return nil, nil
+		}
+		return string(v), nil
+	case net.IP:
+		if v == nil {
+			return nil, nil
+		}
+		return v.String(), nil
+	case [16]byte:
+		return formatUUID(v), nil
+	}
+
+	if vr, ok := value.(driver.Valuer); ok {
+		if !callValuer {
+			return nil, fmt.Errorf("Value() returned another driver.Valuer (%T)", value)
+		}
+		// A nil pointer whose Value method has a value receiver would panic;
+		// database/sql treats it as NULL and so do we.
+		if rv := reflect.ValueOf(vr); rv.Kind() == reflect.Ptr && rv.IsNil() && rv.Type().Elem().Implements(valuerType) {
+			return nil, nil
+		}
+		inner, err := vr.Value()
+		if err != nil {
+			return nil, err
+		}
+		return convertBindValue(inner, false, types)
+	}
+
+	rv := reflect.ValueOf(value)
+
+	// Named array types such as a UUID type declared as [16]byte.
+	if rv.Kind() == reflect.Array && rv.Len() == 16 && rv.Type().Elem().Kind() == reflect.Uint8 {
+		var id [16]byte
+		reflect.Copy(reflect.ValueOf(&id).Elem(), rv)
+		return formatUUID(id), nil
+	}
+
+	// Slices other than []byte are bound as arrays, element by element.
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		arr := make(Array, rv.Len())
+		for idx := range arr {
+			elem, err := convertBindValue(rv.Index(idx).Interface(), true, types)
+			if err != nil {
+				return nil, fmt.Errorf("array element %d: %v", idx, err)
+			}
+			arr[idx] = elem
+		}
+		return arr, nil
+	}
+
+	// Pointers and the other integer, float and named basic kinds.
+	converted, err := driver.DefaultParameterConverter.ConvertValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported argument type %T", value)
+	}
+	return converted, nil
+}
+
+// formatUUID renders a 16 byte UUID in its canonical hyphenated form.
+func formatUUID(id [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// encodeBindArgs converts checked arguments into the values the bind message
+// writes, and rejects anything that would otherwise reach the wire as garbage.
+// Arrays are written as literals of the described parameter types.
+func encodeBindArgs(args []driver.NamedValue, paramTypes []common.ParameterType) ([]driver.NamedValue, error) {
+	encoded := make([]driver.NamedValue, len(args))
+	for idx, arg := range args {
+		encoded[idx] = arg
+		switch v := arg.Value.(type) {
+		case nil, int64, float64, bool, string, []byte:
+		case time.Time:
+			if text, ok := infinityText(v); ok {
+				encoded[idx].Value = text
+			}
+		case Decimal:
+			encoded[idx].Value = string(v)
+		case Interval:
+			var typeOID uint32
+			if idx < len(paramTypes) {
+				typeOID = paramTypes[idx].TypeOID
+			}
+			text, _, err := v.encode(typeOID)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %d: %v", arg.Ordinal, err)
+			}
+			encoded[idx].Value = text
+		case Time:
+			encoded[idx].Value = v.String()
+		case TimeTZ:
+			encoded[idx].Value = v.String()
+		case Array:
+			var t *common.ComplexType
+			if idx < len(paramTypes) {
+				t = common.ParseComplexType(paramTypes[idx].TypeOID, paramTypes[idx].TypeName)
+			}
+			literal, err := encodeArrayLiteral(v, t)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %d: %v", arg.Ordinal, err)
+			}
+			encoded[idx].Value = literal
+		default:
+			return nil, fmt.Errorf("unsupported argument type %T for parameter %d", v, arg.Ordinal)
+		}
+	}
+	return encoded, nil
+}
+
+// encodeArrayLiteral writes arr in the text form the server reads for ARRAY and
+// SET values, e.g. [1,null,3] or ["a","b\"c"]. Elements are quoted or not and
+// times are formatted to suit the element type of t, which may be nil.
+func encodeArrayLiteral(arr Array, t *common.ComplexType) (string, error) {
+	var elemType *common.ComplexType
+	if t != nil {
+		elemType = t.Elem
+	}
+	var elemOID uint32
+	if elemType != nil && elemType.Kind == common.KindScalar {
+		elemOID = elemType.TypeOID
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for idx, elem := range arr {
+		if idx > 0 {
+			sb.WriteByte(',')
+		}
+		var text string
+		quoted := false
+		switch v := elem.(type) {
+		case nil:
+			text = "null"
+		case Array:
+			nested, err := encodeArrayLiteral(v, elemType)
+			if err != nil {
+				return "", err
+			}
+			text = nested
+		case bool:
+			text = strconv.FormatBool(v)
+			quoted = elemOID != 0 && elemOID != common.ColTypeBoolean
+		case int64:
+			text = strconv.FormatInt(v, 10)
+			quoted = !isNumericOID(elemOID)
+		case float64:
+			text = strconv.FormatFloat(v, 'g', -1, 64)
+			quoted = !isNumericOID(elemOID)
+		case Decimal:
+			text = string(v)
+			quoted = !isNumericOID(elemOID)
+		case string:
+			text, quoted = v, true
+		case []byte:
+			text, quoted = escapeBinary(v), true
+		case time.Time:
+			text, quoted = formatArrayTime(v, elemOID), true
+		case Interval:
+			var err error
+			if text, _, err = v.encode(elemOID); err != nil {
+				return "", err
+			}
+			quoted = true
+		case Time:
+			text, quoted = v.String(), true
+		case TimeTZ:
+			text, quoted = v.String(), true
+		default:
+			return "", fmt.Errorf("unsupported array element type %T", elem)
+		}
+		if quoted {
+			text = quoteArrayString(text)
+		}
+		sb.WriteString(text)
+	}
+	sb.WriteByte(']')
+	return sb.String(), nil
+}
+
+// isNumericOID reports whether numbers of this element type are written bare.
+// Unknown (0) element types are treated as numeric.
+func isNumericOID(typeOID uint32) bool {
+	switch typeOID {
+	case 0, common.ColTypeInt64, common.ColTypeFloat64, common.ColTypeNumeric:
+		return true
+	}
+	return false
+}
+
+func quoteArrayString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// escapeBinary writes bytes the way the server prints binary values, with
+// non-printable bytes as \ooo octal escapes.
+func escapeBinary(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch {
+		case c == '\\':
+			sb.WriteString(`\\`)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&sb, `\%03o`, c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func formatArrayTime(t time.Time, typeOID uint32) string {
+	if text, ok := infinityText(t); ok {
+		return text
+	}
+	switch typeOID {
+	case common.ColTypeDate:
+		return t.Format("2006-01-02")
+	case common.ColTypeTime:
+		return t.Format("15:04:05.999999")
+	case common.ColTypeTimeTZ:
+		return t.Format("15:04:05.999999-07:00")
+	case common.ColTypeTimestamp:
+		return t.Format("2006-01-02 15:04:05.999999")
+	}
+	return t.Format("2006-01-02 15:04:05.999999-07:00")
+}
+
+// encodeBinaryParam returns the binary form of an encoded argument when its Go
+// type matches the parameter type, and false when it has to be sent as text.
+func encodeBinaryParam(value interface{}, typeOID uint32) ([]byte, bool) {
+	switch v := value.(type) {
+	case int64:
+		if typeOID == common.ColTypeInt64 {
+			return binaryUint64(uint64(v)), true
+		}
+	case float64:
+		if typeOID == common.ColTypeFloat64 {
+			return binaryUint64(math.Float64bits(v)), true
+		}
+	case bool:
+		if typeOID == common.ColTypeBoolean {
+			if v {
+				return []byte{1}, true
+			}
+			return []byte{0}, true
+		}
+	case time.Time:
+		switch typeOID {
+		case common.ColTypeTimestampTZ:
+			return binaryUint64(uint64(binaryEpochMicros(v))), true
+		case common.ColTypeTimestamp:
+			// A TIMESTAMP keeps the wall clock and drops the zone, as the
+			// server does for a text literal with an offset.
+			wall := time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), time.UTC)
+			return binaryUint64(uint64(binaryEpochMicros(wall))), true
+		case common.ColTypeDate:
+			day := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC)
+			return binaryUint64(uint64(binaryEpochMicros(day) / microsPerDay)), true
+		}
+	case []byte:
+		if v == nil {
+			return nil, false
+		}
+		switch typeOID {
+		case common.ColTypeVarBinary, common.ColTypeLongVarBinary, common.ColTypeBinary:
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func binaryUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// binaryEpochMicros returns the microseconds from the binary epoch to t,
+// without going through a time.Duration, which cannot span every timestamp.
+func binaryEpochMicros(t time.Time) int64 {
+	return (t.Unix()-binaryEpoch.Unix())*microsPerSecond + int64(t.Nanosecond()/1000)
+}
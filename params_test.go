@@ -0,0 +1,332 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+)
+
+type testUUID [16]byte
+
+type testValuer struct {
+	value interface{}
+	err   error
+}
+
+func (v testValuer) Value() (driver.Value, error) {
+	return v.value, v.err
+}
+
+type testPtrValuer struct{}
+
+func (v *testPtrValuer) Value() (driver.Value, error) {
+	return "from pointer", nil
+}
+
+type testLoopValuer struct{}
+
+func (v testLoopValuer) Value() (driver.Value, error) {
+	return v, nil
+}
+
+func TestCheckNamedValue(t *testing.T) {
+	conn := &connection{}
+	now := time.Now()
+	id := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	var nilValuer *testValuer
+	var nilPtrValuer *testPtrValuer
+	var nilInt *big.Int
+	str := "pointed"
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{name: "nil", value: nil, expected: nil},
+		{name: "string", value: "text", expected: "text"},
+		{name: "time", value: now, expected: now},
+		{name: "int", value: 42, expected: int64(42)},
+		{name: "float32", value: float32(0.5), expected: float64(0.5)},
+		{name: "pointer", value: &str, expected: "pointed"},
+		{name: "small uint64", value: uint64(7), expected: int64(7)},
+		{name: "large uint64", value: uint64(math.MaxUint64), expected: Decimal("18446744073709551615")},
+		{name: "json.RawMessage", value: json.RawMessage(`{"a":1}`), expected: `{"a":1}`},
+		{name: "nil json.RawMessage", value: json.RawMessage(nil), expected: nil},
+		{name: "big.Int", value: new(big.Int).Lsh(big.NewInt(1), 100), expected: Decimal("1267650600228229401496703205376")},
+		{name: "nil big.Int", value: nilInt, expected: nil},
+		{name: "IPv4", value: net.ParseIP("192.168.0.1"), expected: "192.168.0.1"},
+		{name: "IPv6", value: net.ParseIP("2001:db8::1"), expected: "2001:db8::1"},
+		{name: "UUID array", value: id, expected: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "named UUID array", value: testUUID(id), expected: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "valuer", value: testValuer{value: "valued"}, expected: "valued"},
+		{name: "valuer returning uint64", value: testValuer{value: uint64(3)}, expected: int64(3)},
+		{name: "nil valuer", value: nilValuer, expected: nil},
+		{name: "pointer valuer", value: &testPtrValuer{}, expected: "from pointer"},
+		{name: "duration", value: 90 * time.Minute, expected: Interval{Microseconds: 90 * microsPerMinute}},
+		{name: "interval", value: Interval{Months: 1}, expected: Interval{Months: 1}},
+		{name: "int64 slice", value: []int64{1, 2}, expected: Array{int64(1), int64(2)}},
+		{name: "int slice", value: []int{3}, expected: Array{int64(3)}},
+		{name: "string slice", value: []string{"a", ""}, expected: Array{"a", ""}},
+		{name: "nil slice", value: []string(nil), expected: nil},
+		{name: "empty slice", value: []bool{}, expected: Array{}},
+		{name: "pointer slice", value: []*string{&str, nil}, expected: Array{"pointed", nil}},
+		{name: "nested slice", value: [][]float64{{1.5}, nil}, expected: Array{Array{1.5}, nil}},
+		{name: "Array", value: Array{uint64(1), nil, big.NewInt(2)}, expected: Array{int64(1), nil, Decimal("2")}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nv := &driver.NamedValue{Ordinal: 1, Value: tc.value}
+			if err := conn.CheckNamedValue(nv); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got, ok := nv.Value.(time.Time); ok {
+				if !got.Equal(tc.expected.(time.Time)) {
+					t.Errorf("expected %v, got %v", tc.expected, got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(nv.Value, tc.expected) {
+				t.Errorf("expected %T(%v), got %T(%v)", tc.expected, tc.expected, nv.Value, nv.Value)
+			}
+		})
+	}
+
+	// A nil pointer to a type whose Value method needs the pointer is called.
+	nv := &driver.NamedValue{Value: nilPtrValuer}
+	if err := conn.CheckNamedValue(nv); err != nil || nv.Value != "from pointer" {
+		t.Errorf("expected the pointer receiver to be called, got %v, %v", nv.Value, err)
+	}
+}
+
+func TestCheckNamedValueRejects(t *testing.T) {
+	conn := &connection{}
+
+	testCases := []struct {
+		name  string
+		value interface{}
+		err   string
+	}{
+		{name: "struct", value: struct{ A int }{}, err: "unsupported argument type struct { A int }"},
+		{name: "map", value: map[string]int{}, err: "unsupported argument type map[string]int"},
+		{name: "short array", value: [4]byte{}, err: "unsupported argument type [4]uint8"},
+		{name: "channel", value: make(chan int), err: "unsupported argument type chan int"},
+		{name: "valuer error", value: testValuer{err: errors.New("no value")}, err: "no value"},
+		{name: "valuer returning a struct", value: testValuer{value: struct{}{}}, err: "unsupported argument type struct {}"},
+		{name: "valuer returning a valuer", value: testLoopValuer{}, err: "returned another driver.Valuer"},
+		{name: "slice of structs", value: []struct{}{{}}, err: "array element 0: unsupported argument type struct {}"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := conn.CheckNamedValue(&driver.NamedValue{Ordinal: 1, Value: tc.value})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestEncodeBindArgsInterval(t *testing.T) {
+	encoded, err := encodeBindArgs([]driver.NamedValue{{Ordinal: 1, Value: Interval{Days: 2, Microseconds: microsPerHour}}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded[0].Value != "2 days 1 hour" {
+		t.Errorf("unexpected bind value %v", encoded[0].Value)
+	}
+}
+
+func TestEncodeBindArgsIntervalRanges(t *testing.T) {
+	yearMonth := Interval{Months: 14}
+	encoded, err := encodeBindArgs([]driver.NamedValue{{Ordinal: 1, Value: yearMonth}},
+		[]common.ParameterType{{TypeOID: common.ColTypeIntervalYM}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoded[0].Value != "1 year 2 months" {
+		t.Errorf("unexpected bind value %v", encoded[0].Value)
+	}
+
+	testCases := []struct {
+		name      string
+		value     interface{}
+		paramType common.ParameterType
+		err       string
+	}{
+		{"mixed", Interval{Months: 1, Days: 2}, common.ParameterType{}, "mixes months with days or seconds"},
+		{"months for day-time", yearMonth, common.ParameterType{TypeOID: common.ColTypeInterval}, "cannot be a day-time interval"},
+		{"days for year-month", Interval{Days: 1}, common.ParameterType{TypeOID: common.ColTypeIntervalYM}, "cannot be a year-month interval"},
+		{"mixed array element", Array{Interval{Months: 1, Microseconds: 1}}, common.ParameterType{}, "mixes months with days or seconds"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := encodeBindArgs([]driver.NamedValue{{Ordinal: 1, Value: tc.value}}, []common.ParameterType{tc.paramType})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestEncodeBindArgsRejectsUnchecked(t *testing.T) {
+	_, err := encodeBindArgs([]driver.NamedValue{{Ordinal: 2, Value: uint32(1)}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported argument type uint32 for parameter 2") {
+		t.Errorf("expected an unsupported type error, got %v", err)
+	}
+}
+
+func TestEncodeArrayLiteral(t *testing.T) {
+	date := time.Date(2024, 2, 29, 13, 14, 15, 500000000, time.FixedZone("", -5*3600))
+	testCases := []struct {
+		name      string
+		value     Array
+		paramType common.ParameterType
+		expected  string
+	}{
+		{name: "ints", value: Array{int64(1), nil, int64(3)}, paramType: common.ParameterType{TypeOID: 1506}, expected: "[1,null,3]"},
+		{name: "strings", value: Array{"a", `b"c`, `d\e`}, paramType: common.ParameterType{TypeOID: 1509}, expected: `["a","b\"c","d\\e"]`},
+		{name: "numbers as strings", value: Array{int64(1), 2.5, true}, paramType: common.ParameterType{TypeOID: 1509}, expected: `["1","2.5","true"]`},
+		{name: "numeric set", value: Array{Decimal("1.10"), 2.5}, paramType: common.ParameterType{TypeOID: 2716}, expected: "[1.10,2.5]"},
+		{name: "bools", value: Array{true, false}, paramType: common.ParameterType{TypeOID: 1505}, expected: "[true,false]"},
+		{name: "dates", value: Array{date}, paramType: common.ParameterType{TypeOID: 1510}, expected: `["2024-02-29"]`},
+		{name: "timestamps", value: Array{date}, paramType: common.ParameterType{TypeOID: 1512}, expected: `["2024-02-29 13:14:15.5"]`},
+		{name: "timestamptz", value: Array{date}, paramType: common.ParameterType{TypeOID: 1513}, expected: `["2024-02-29 13:14:15.5-05:00"]`},
+		{name: "binary", value: Array{[]byte{'a', 0, '\\'}}, paramType: common.ParameterType{TypeOID: 1517}, expected: `["a\\000\\\\"]`},
+		{name: "nested", value: Array{Array{int64(1)}, Array{}}, paramType: common.ParameterType{TypeOID: common.ColTypeArray, TypeName: "ARRAY[ARRAY[INT]]"}, expected: "[[1],[]]"},
+		{name: "undescribed", value: Array{int64(1), "x"}, expected: `[1,"x"]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := []driver.NamedValue{{Ordinal: 1, Value: tc.value}}
+			encoded, err := encodeBindArgs(args, []common.ParameterType{tc.paramType})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if encoded[0].Value != tc.expected {
+				t.Errorf("expected %s, got %v", tc.expected, encoded[0].Value)
+			}
+		})
+	}
+
+	_, err := encodeBindArgs([]driver.NamedValue{{Ordinal: 1, Value: Array{struct{}{}}}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported array element type struct {}") {
+		t.Errorf("expected an unsupported element error, got %v", err)
+	}
+}
+
+func TestEncodeBinaryParam(t *testing.T) {
+	tz := time.FixedZone("", -5*3600)
+	testCases := []struct {
+		name     string
+		value    interface{}
+		typeOID  uint32
+		expected []byte
+	}{
+		{name: "int", value: int64(-2), typeOID: common.ColTypeInt64, expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}},
+		{name: "float", value: 0.1, typeOID: common.ColTypeFloat64, expected: []byte{0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}},
+		{name: "bool", value: true, typeOID: common.ColTypeBoolean, expected: []byte{1}},
+		{name: "timestamptz", value: time.Date(2000, 1, 1, 0, 0, 1, 500000000, time.UTC), typeOID: common.ColTypeTimestampTZ, expected: []byte{0, 0, 0, 0, 0, 0x16, 0xe3, 0x60}},
+		{name: "timestamptz offset", value: time.Date(1999, 12, 31, 19, 0, 1, 500000000, tz), typeOID: common.ColTypeTimestampTZ, expected: []byte{0, 0, 0, 0, 0, 0x16, 0xe3, 0x60}},
+		{name: "timestamp wall clock", value: time.Date(2000, 1, 1, 0, 0, 1, 500000000, tz), typeOID: common.ColTypeTimestamp, expected: []byte{0, 0, 0, 0, 0, 0x16, 0xe3, 0x60}},
+		{name: "date", value: time.Date(1999, 12, 31, 23, 0, 0, 0, tz), typeOID: common.ColTypeDate, expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "binary", value: []byte{'\\', 0}, typeOID: common.ColTypeVarBinary, expected: []byte{'\\', 0}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, ok := encodeBinaryParam(tc.value, tc.typeOID)
+			if !ok {
+				t.Fatalf("expected %v to be sent in binary", tc.value)
+			}
+			if !reflect.DeepEqual(encoded, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, encoded)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		value   interface{}
+		typeOID uint32
+	}{
+		{value: int64(1), typeOID: common.ColTypeNumeric},
+		{value: "1", typeOID: common.ColTypeInt64},
+		{value: []byte(nil), typeOID: common.ColTypeVarBinary},
+		{value: []byte("a"), typeOID: common.ColTypeVarChar},
+		{value: time.Now(), typeOID: common.ColTypeTime},
+	} {
+		if _, ok := encodeBinaryParam(tc.value, tc.typeOID); ok {
+			t.Errorf("expected %#v to be sent as text for type %d", tc.value, tc.typeOID)
+		}
+	}
+}
+
+func TestBindMessageParamFormats(t *testing.T) {
+	s := &stmt{
+		conn:         &connection{config: Config{BinaryParameters: true}},
+		preparedName: "S1",
+		paramTypes: []common.ParameterType{
+			{TypeOID: common.ColTypeInt64},
+			{TypeOID: common.ColTypeVarChar},
+		},
+	}
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(7)}, {Ordinal: 2, Value: "x"}}
+
+	bind, err := s.bindMessage("", args, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(bind.ParamFormats, []uint16{1, 0}) {
+		t.Errorf("unexpected param formats %v", bind.ParamFormats)
+	}
+	if !reflect.DeepEqual(bind.NamedArgs[0].Value, []byte{0, 0, 0, 0, 0, 0, 0, 7}) || bind.NamedArgs[1].Value != "x" {
+		t.Errorf("unexpected arguments %v", bind.NamedArgs)
+	}
+	if args[0].Value != int64(7) {
+		t.Errorf("expected the caller's arguments to be left alone, got %v", args[0].Value)
+	}
+
+	s.conn.config.BinaryParameters = false
+	if bind, err = s.bindMessage("", args, nil); err != nil || bind.ParamFormats != nil {
+		t.Errorf("expected text arguments without binary_parameters, got %v, %v", bind.ParamFormats, err)
+	}
+}
@@ -0,0 +1,71 @@
+package parse
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// OffsetMap maps byte offsets in SQL the driver rewrote back to the SQL it
+// was rewritten from, so that a position the server reports can be shown in
+// the statement the caller wrote. See WithOffsetMap and SplitStatementsMapped.
+type OffsetMap struct {
+	edits []edit
+}
+
+// edit records that input[inStart:inEnd] became output[outStart:outEnd].
+// Edits are kept in the order they were made.
+type edit struct {
+	inStart, inEnd   int
+	outStart, outEnd int
+}
+
+func (m *OffsetMap) add(inStart, inEnd, outStart, outEnd int) {
+	m.edits = append(m.edits, edit{inStart: inStart, inEnd: inEnd, outStart: outStart, outEnd: outEnd})
+}
+
+// InputOffset returns the offset in the original SQL of the byte at offset in
+// the rewritten SQL. An offset inside a substituted value maps to the start of
+// what it replaced. A nil map leaves offsets unchanged.
+func (m *OffsetMap) InputOffset(offset int) int {
+	if m == nil {
+		return offset
+	}
+	delta := 0
+	for _, e := range m.edits {
+		if offset < e.outStart {
+			break
+		}
+		if offset < e.outEnd {
+			return e.inStart
+		}
+		delta = e.inEnd - e.outEnd
+	}
+	return offset + delta
+}
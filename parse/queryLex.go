@@ -52,6 +52,7 @@ type Lexer struct {
 	width        int
 	onNamed      OnNamedParam
 	onPositional SubstitutePosParam
+	offsets      *OffsetMap
 	output       strings.Builder
 }
 
@@ -72,6 +73,15 @@ func WithNamedCallback(cb OnNamedParam) LexOption {
 	}
 }
 
+// WithOffsetMap records in m where each substitution lands in the output, so
+// that offsets in the output can be mapped back to the query
+func WithOffsetMap(m *OffsetMap) LexOption {
+	return func(l *Lexer) {
+		*m = OffsetMap{}
+		l.offsets = m
+	}
+}
+
 // LexOptions converts an aritrary number of options into one function
 // for easier handling
 func LexOptions(opts ...LexOption) LexOption {
@@ -197,6 +207,14 @@ func (l *Lexer) done() bool {
 	return l.pos >= len(l.input)
 }
 
+// recordSubstitution notes that input[inStart:l.pos] was replaced by what was
+// written to the output since outStart.
+func (l *Lexer) recordSubstitution(inStart, outStart int) {
+	if l.offsets != nil {
+		l.offsets.add(inStart, l.pos, outStart, l.output.Len())
+	}
+}
+
 func (l *Lexer) writeChunk() {
 	l.output.WriteString(l.input[l.start:l.pos])
 	l.start = l.pos
@@ -258,13 +276,16 @@ func lexNamedParam(l *Lexer) stateFunc {
 	// write everything before the @
 	l.backup()
 	l.writeChunk()
+	at := l.pos
 	l.next()
 	l.start = l.pos
 	// advance through a valid named-parameter token
 	l.consumeNamedParam()
 	l.onNamed(strings.ToUpper(l.input[l.start:l.pos]))
 	l.start = l.pos
+	out := l.output.Len()
 	l.output.WriteRune('?')
+	l.recordSubstitution(at, out)
 	return lexQuery
 }
 
@@ -273,8 +294,10 @@ func lexNamedParam(l *Lexer) stateFunc {
 func lexPositional(l *Lexer) stateFunc {
 	l.backup()
 	l.writeChunk()
+	at, out := l.pos, l.output.Len()
 	l.output.WriteString(l.onPositional())
 	l.next()
 	l.start = l.pos
+	l.recordSubstitution(at, out)
 	return lexQuery
 }
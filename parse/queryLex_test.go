@@ -32,7 +32,10 @@ package parse
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestSkipUntil(t *testing.T) {
 	lexer := Lexer{input: "select * from a where test = 'b'"}
@@ -254,3 +257,31 @@ func TestLexPositional(t *testing.T) {
 		})
 	}
 }
+
+func TestLexOffsetMap(t *testing.T) {
+	query := "select * from t where a = @first and b = ? and c = 'x' and d = @second and oops"
+	var offsets OffsetMap
+	result := Lex(query, WithOffsetMap(&offsets), WithPositionalSubstitution(swapPos))
+	expected := "select * from t where a = ? and b = 'replaced' and c = 'x' and d = ? and oops"
+	if result != expected {
+		t.Fatalf("Expected query:\n%s\nGot:\n%s", expected, result)
+	}
+
+	for _, token := range []string{"select", "and b", "and c", "and d", "oops"} {
+		if got, want := offsets.InputOffset(strings.Index(result, token)), strings.Index(query, token); got != want {
+			t.Errorf("%q: expected offset %d, got %d", token, want, got)
+		}
+	}
+	// Offsets inside a substitution map to the placeholder it replaced.
+	if got, want := offsets.InputOffset(strings.Index(result, "replaced")+3), strings.Index(query, "?"); got != want {
+		t.Errorf("expected offset %d, got %d", want, got)
+	}
+	if got, want := offsets.InputOffset(strings.LastIndex(result, "?")), strings.Index(query, "@second"); got != want {
+		t.Errorf("expected offset %d, got %d", want, got)
+	}
+
+	var nilMap *OffsetMap
+	if nilMap.InputOffset(7) != 7 {
+		t.Error("a nil map should leave offsets unchanged")
+	}
+}
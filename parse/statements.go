@@ -22,13 +22,27 @@ import (
 // SplitStatements breaks a SQL string into individual statements separated by semicolons
 // that are not contained within literals or comments.
 func SplitStatements(query string) []string {
+	statements, _ := SplitStatementsMapped(query)
+	return statements
+}
+
+// SplitStatementsMapped is SplitStatements that also returns, for each statement, a map
+// from offsets in the statement to offsets in query. Statements differ from query by the
+// surrounding whitespace and the line comments, which are dropped.
+func SplitStatementsMapped(query string) ([]string, []*OffsetMap) {
 	trimmed := strings.TrimSpace(query)
 	if trimmed == "" {
-		return nil
+		return nil, nil
 	}
 
 	var statements []string
+	var offsets []*OffsetMap
 	var current strings.Builder
+	// Where the current statement begins in query, and the comments dropped
+	// from it so far, at their offsets in current.
+	currentStart := 0
+	var dropped []edit
+	commentStart := 0
 	// Track our current lexical state so we can ignore semicolons that live
 	// inside literals or comments.
 	inSingleQuote := false
@@ -44,12 +58,15 @@ func SplitStatements(query string) []string {
 		}
 	}
 	flush := func() {
-		statement := strings.TrimSpace(current.String())
+		raw := current.String()
+		statement := strings.TrimSpace(raw)
 		current.Reset()
 		if statement != "" && statementHasContent {
 			statements = append(statements, statement)
+			offsets = append(offsets, statementOffsets(raw, currentStart, dropped))
 		}
 		statementHasContent = false
+		dropped = nil
 	}
 
 	i := 0
@@ -59,6 +76,7 @@ func SplitStatements(query string) []string {
 		if inLineComment {
 			// Swallow comment text but keep the newline terminator so tokens remain separated.
 			if ch == '\n' || ch == '\r' {
+				dropped = append(dropped, edit{inStart: commentStart, inEnd: i, outStart: current.Len(), outEnd: current.Len()})
 				current.WriteByte(ch)
 				inLineComment = false
 			}
@@ -148,6 +166,7 @@ func SplitStatements(query string) []string {
 		}
 
 		if ch == '-' && i+1 < len(query) && query[i+1] == '-' {
+			commentStart = i
 			i += 2
 			inLineComment = true
 			continue
@@ -163,6 +182,7 @@ func SplitStatements(query string) []string {
 				continue
 			}
 			if next == '/' {
+				commentStart = i
 				i += 2
 				inLineComment = true
 				continue
@@ -182,6 +202,7 @@ func SplitStatements(query string) []string {
 		if ch == ';' {
 			flush()
 			i++
+			currentStart = i
 			continue
 		}
 
@@ -190,8 +211,36 @@ func SplitStatements(query string) []string {
 		i++
 	}
 
+	if inLineComment {
+		dropped = append(dropped, edit{inStart: commentStart, inEnd: len(query), outStart: current.Len(), outEnd: current.Len()})
+	}
 	flush()
-	return statements
+	return statements, offsets
+}
+
+// statementOffsets maps offsets in the statement trimmed from raw back to
+// query, given where raw begins in query and the comments dropped from it.
+func statementOffsets(raw string, rawStart int, dropped []edit) *OffsetMap {
+	lead := len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+
+	// Everything in query before the statement's first character, including
+	// the comments in its leading whitespace, is skipped at once.
+	start := rawStart + lead
+	m := &OffsetMap{}
+	for _, e := range dropped {
+		if e.outStart <= lead {
+			start += e.inEnd - e.inStart
+			continue
+		}
+		if len(m.edits) == 0 {
+			m.add(0, start, 0, 0)
+		}
+		m.add(e.inStart, e.inEnd, e.outStart-lead, e.outEnd-lead)
+	}
+	if len(m.edits) == 0 {
+		m.add(0, start, 0, 0)
+	}
+	return m
 }
 
 func readDollarTag(query string, start int) (string, int, bool) {
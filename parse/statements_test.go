@@ -2,6 +2,7 @@ package parse
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -63,3 +64,26 @@ SELECT 1; /* block;comment */ SELECT 2;`,
 		})
 	}
 }
+
+func TestSplitStatementsMapped(t *testing.T) {
+	query := "  -- set up\n  CREATE TABLE t (a int); INSERT -- one row\n INTO t VALUES (1);\n// done\nSELECT oops FROM t"
+	statements, offsets := SplitStatementsMapped(query)
+	expected := []string{"CREATE TABLE t (a int)", "INSERT \n INTO t VALUES (1)", "SELECT oops FROM t"}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Fatalf("expected %q, got %q", expected, statements)
+	}
+	if len(offsets) != len(statements) {
+		t.Fatalf("expected %d offset maps, got %d", len(statements), len(offsets))
+	}
+
+	for idx, token := range []string{"CREATE", "INSERT", "SELECT"} {
+		if got, want := offsets[idx].InputOffset(0), strings.Index(query, token); got != want {
+			t.Errorf("statement %d: expected to start at %d, got %d", idx, want, got)
+		}
+	}
+	for idx, token := range []string{"int", "INTO t", "oops"} {
+		if got, want := offsets[idx].InputOffset(strings.Index(statements[idx], token)), strings.Index(query, token); got != want {
+			t.Errorf("%q: expected offset %d, got %d", token, want, got)
+		}
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS array_params_test;
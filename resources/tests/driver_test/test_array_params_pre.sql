@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS array_params_test;
+CREATE TABLE array_params_test(id int, tags ARRAY[varchar(20)], days SET[date]);
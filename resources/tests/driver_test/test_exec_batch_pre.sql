@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS batch_test;
+CREATE TABLE batch_test(id int PRIMARY KEY ENABLED, name varchar(64));
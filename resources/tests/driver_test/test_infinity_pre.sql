@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS infinity_test;
+CREATE TABLE infinity_test(id int, valid_from timestamptz, valid_to timestamptz, day date);
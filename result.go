@@ -32,9 +32,56 @@ package vertigo
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Result is the driver.Result returned by this driver for an Exec. The
+// database/sql package hides it behind its own type, so it can only be reached
+// by executing a statement through sql.Conn.Raw:
+//
+//	err = conn.Raw(func(driverConn interface{}) error {
+//		stmt, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+//		...
+//		res, err := stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+//		command := res.(vertigo.Result).Command()
+//		...
+//	})
+type Result interface {
+	driver.Result
+
+	// Command returns the kind of statement that ran, such as "INSERT",
+	// "COPY" or "CREATE TABLE", as reported by the server. It is empty if the
+	// server did not report one.
+	Command() string
+
+	// Statements returns the outcome of each statement that ran, in order.
+	// There is more than one when a multi-statement string was executed
+	// without server-side prepared statements; RowsAffected and Command then
+	// add up and report the last of them respectively.
+	Statements() []StatementResult
+
+	// Notices returns the notices the server sent while the statements ran.
+	Notices() []*Notice
+}
+
+// StatementResult is the outcome of one statement of an Exec.
+type StatementResult struct {
+	Statement    string    // the SQL that ran
+	Command      string    // the kind of statement, see Result.Command
+	RowsAffected int64     // the number of rows the statement inserted, updated, deleted or loaded
+	Notices      []*Notice // the notices the server sent while the statement ran
+}
+
 type result struct {
 	lastInsertID int64
 	rowsAffected int64
+	command      string
+	statements   []StatementResult
 }
 
 func (r *result) LastInsertId() (int64, error) {
@@ -44,3 +91,120 @@ func (r *result) LastInsertId() (int64, error) {
 func (r *result) RowsAffected() (int64, error) {
 	return r.rowsAffected, nil
 }
+
+// Command returns the kind of statement that produced the result.
+func (r *result) Command() string {
+	return r.command
+}
+
+// Statements returns the outcome of each statement that ran.
+func (r *result) Statements() []StatementResult {
+	return r.statements
+}
+
+// Notices returns the notices of every statement that ran, in order.
+func (r *result) Notices() []*Notice {
+	var notices []*Notice
+	for _, st := range r.statements {
+		notices = append(notices, st.Notices...)
+	}
+	return notices
+}
+
+// parseCommandTag splits a command tag such as "INSERT 0 5", "UPDATE 3" or
+// "CREATE TABLE" into the statement kind and, if the tag ends in one, the
+// row count.
+func parseCommandTag(tag string) (command string, count int64, hasCount bool) {
+	fields := strings.Fields(tag)
+	end := len(fields)
+	for end > 0 {
+		if _, err := strconv.ParseUint(fields[end-1], 10, 64); err != nil {
+			break
+		}
+		end--
+	}
+	if end < len(fields) {
+		if n, err := strconv.ParseInt(fields[len(fields)-1], 10, 64); err == nil {
+			count, hasCount = n, true
+		}
+	}
+	return strings.ToUpper(strings.Join(fields[:end], " ")), count, hasCount
+}
+
+// execResult drains the rows of an Exec and builds its result. A
+// multi-statement result adds up the rows each statement affected and reports
+// the kind of the last one.
+func execResult(dr driver.Rows) (*result, error) {
+	sets := []*rows{}
+	switch r := dr.(type) {
+	case *rows:
+		sets = append(sets, r)
+	case *multiRows:
+		sets = r.sets
+	}
+
+	res := &result{statements: make([]StatementResult, 0, len(sets))}
+	for _, set := range sets {
+		setResult, err := newResult(set)
+		if err != nil {
+			return nil, err
+		}
+		res.rowsAffected += setResult.rowsAffected
+		res.command = setResult.command
+		res.statements = append(res.statements, StatementResult{
+			Statement:    set.statement,
+			Command:      setResult.command,
+			RowsAffected: setResult.rowsAffected,
+			Notices:      set.Notices(),
+		})
+	}
+	return res, nil
+}
+
+// newResult drains a statement's rows and builds its result. The affected row
+// count comes from the command tag when the server included one. Otherwise a
+// SELECT reports the rows it returned, and any other statement the single
+// value of the row Vertica sends back for DML and COPY. The statement has
+// already run, so a value that cannot be decoded only keeps it from being
+// used as the count.
+func newResult(rs *rows) (*result, error) {
+	res := &result{}
+	var count, value int64
+	hasValue := false
+	vals := make([]driver.Value, len(rs.Columns()))
+	for {
+		err := rs.Next(vals)
+		if err == io.EOF {
+			break
+		}
+		var decodeErr *DecodeError
+		if errors.As(err, &decodeErr) {
+			count++
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		count++
+		if count == 1 && len(vals) == 1 {
+			switch v := vals[0].(type) {
+			case int:
+				value, hasValue = int64(v), true
+			case int64:
+				value, hasValue = v, true
+			}
+		}
+	}
+
+	command, tagCount, hasCount := parseCommandTag(rs.tag())
+	res.command = command
+	switch {
+	case hasCount:
+		res.rowsAffected = tagCount
+	case command == "SELECT":
+		res.rowsAffected = count
+	case count == 1 && hasValue:
+		res.rowsAffected = value
+	}
+	return res, nil
+}
@@ -0,0 +1,160 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestParseCommandTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		command  string
+		count    int64
+		hasCount bool
+	}{
+		{tag: "INSERT 0 5", command: "INSERT", count: 5, hasCount: true},
+		{tag: "UPDATE 3", command: "UPDATE", count: 3, hasCount: true},
+		{tag: "COPY 1000", command: "COPY", count: 1000, hasCount: true},
+		{tag: "DELETE", command: "DELETE"},
+		{tag: "CREATE TABLE", command: "CREATE TABLE"},
+		{tag: "select", command: "SELECT"},
+		{tag: "", command: ""},
+	}
+	for _, tc := range tests {
+		command, count, hasCount := parseCommandTag(tc.tag)
+		if command != tc.command || count != tc.count || hasCount != tc.hasCount {
+			t.Errorf("parseCommandTag(%q) = %q, %d, %v; want %q, %d, %v",
+				tc.tag, command, count, hasCount, tc.command, tc.count, tc.hasCount)
+		}
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	intDesc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "OUTPUT", DataTypeOID: common.ColTypeInt64, DataTypeName: "int"},
+	}}
+	textDesc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "name", DataTypeOID: common.ColTypeVarChar, DataTypeName: "varchar"},
+	}}
+
+	tests := []struct {
+		name     string
+		rows     *rows
+		tag      string
+		command  string
+		affected int64
+	}{
+		{name: "tag count", rows: newTestRows(t, intDesc, []string{"7"}), tag: "INSERT 0 5", command: "INSERT", affected: 5},
+		{name: "output row", rows: newTestRows(t, intDesc, []string{"7"}), tag: "INSERT", command: "INSERT", affected: 7},
+		{name: "select", rows: newTestRows(t, intDesc, []string{"7"}, []string{"8"}), tag: "SELECT", command: "SELECT", affected: 2},
+		{name: "select one integer", rows: newTestRows(t, intDesc, []string{"7"}), tag: "SELECT", command: "SELECT", affected: 1},
+		{name: "no rows", rows: newEmptyRows(), tag: "CREATE TABLE", command: "CREATE TABLE"},
+		{name: "undecodable output with tag", rows: newTestRows(t, intDesc, []string{"x"}), tag: "INSERT 0 5", command: "INSERT", affected: 5},
+		{name: "undecodable output", rows: newTestRows(t, intDesc, []string{"x"}), tag: "INSERT", command: "INSERT"},
+		{name: "undecodable select", rows: newTestRows(t, intDesc, []string{"x"}, []string{"8"}), tag: "SELECT", command: "SELECT", affected: 2},
+		{name: "text column", rows: newTestRows(t, textDesc, []string{"Joe"}), command: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.rows.commandTag = tc.tag
+			res, err := newResult(tc.rows)
+			if err != nil {
+				t.Fatalf("newResult: %v", err)
+			}
+			affected, _ := res.RowsAffected()
+			if affected != tc.affected {
+				t.Errorf("RowsAffected() = %d, want %d", affected, tc.affected)
+			}
+			if res.Command() != tc.command {
+				t.Errorf("Command() = %q, want %q", res.Command(), tc.command)
+			}
+		})
+	}
+}
+
+func TestExecResultStatements(t *testing.T) {
+	intDesc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "OUTPUT", DataTypeOID: common.ColTypeInt64, DataTypeName: "int"},
+	}}
+
+	create := newEmptyRows()
+	create.statement = "CREATE TABLE t (a int)"
+	create.commandTag = "CREATE TABLE"
+	insert := newTestRows(t, intDesc, []string{"3"})
+	insert.statement = "INSERT INTO t SELECT * FROM s"
+	insert.commandTag = "INSERT"
+	insert.notices = []*Notice{{Severity: "NOTICE", Message: "3 rows copied"}}
+	update := newEmptyRows()
+	update.statement = "UPDATE t SET a = 1"
+	update.commandTag = "UPDATE 2"
+
+	res, err := execResult(&multiRows{sets: []*rows{create, insert, update}})
+	if err != nil {
+		t.Fatalf("execResult: %v", err)
+	}
+	affected, _ := res.RowsAffected()
+	if affected != 5 {
+		t.Errorf("RowsAffected() = %d, want 5", affected)
+	}
+	if res.Command() != "UPDATE" {
+		t.Errorf("Command() = %q, want UPDATE", res.Command())
+	}
+
+	statements := res.Statements()
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(statements))
+	}
+	want := []StatementResult{
+		{Statement: create.statement, Command: "CREATE TABLE"},
+		{Statement: insert.statement, Command: "INSERT", RowsAffected: 3, Notices: insert.notices},
+		{Statement: update.statement, Command: "UPDATE", RowsAffected: 2},
+	}
+	for i, got := range statements {
+		if got.Statement != want[i].Statement || got.Command != want[i].Command || got.RowsAffected != want[i].RowsAffected {
+			t.Errorf("statement %d: got %+v, want %+v", i, got, want[i])
+		}
+		if len(got.Notices) != len(want[i].Notices) {
+			t.Errorf("statement %d: got %d notices, want %d", i, len(got.Notices), len(want[i].Notices))
+		}
+	}
+	if statements[1].Notices[0].Message != "3 rows copied" {
+		t.Errorf("unexpected notice %+v", statements[1].Notices[0])
+	}
+	if notices := res.Notices(); len(notices) != 1 || notices[0] != insert.notices[0] {
+		t.Errorf("unexpected notices %v", notices)
+	}
+}
@@ -0,0 +1,210 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/parse"
+)
+
+// RetryPolicy makes the driver run a statement again when it fails with a
+// transient error, such as a lock timeout. It only applies to statements that
+// are safe to run again: SELECTs that read from a table, without INTO or
+// NEXTVAL, run with autocommit; and statements marked idempotent with
+// VerticaContext.SetIdempotent. Neither is retried inside a transaction. A
+// SELECT that calls a function with side effects on each row of a table is not
+// told apart, and should be run with a policy of a single attempt. Only the
+// error returned when the statement is run is retried, not one met later while
+// reading a streamed result.
+//
+// When the error cost the connection its session, a new session is opened
+// before the next attempt. Other errors, such as a lock timeout, are retried in
+// the same session. It does not keep anything set on the old one, such
+// as the settings of SET statements or temporary tables.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a statement is run at most, including
+	// the first. Values below 2 turn retries off.
+	MaxAttempts int
+
+	// Backoff returns how long to wait before the given retry, counting from 1.
+	// If nil the statement is run again at once.
+	Backoff func(retry int) time.Duration
+
+	// Retryable reports whether a statement that failed with err may succeed if
+	// it is run again. If nil, IsRetryable is used.
+	Retryable func(err error) bool
+}
+
+// ExponentialBackoff returns a RetryPolicy.Backoff that waits initial before
+// the first retry and twice as long before each one after it, up to max.
+func ExponentialBackoff(initial, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		wait := initial
+		for i := 1; i < retry && wait < max; i++ {
+			wait *= 2
+		}
+		if wait > max {
+			wait = max
+		}
+		return wait
+	}
+}
+
+// RetrySQLStates returns a RetryPolicy.Retryable that retries the errors
+// reported by the server with one of the given SQLSTATEs or Vertica error
+// codes, and lost connections.
+func RetrySQLStates(codes ...string) func(err error) bool {
+	return func(err error) bool {
+		var vErr *VError
+		if errors.As(err, &vErr) {
+			for _, code := range codes {
+				if vErr.SQLState == code || vErr.ErrorCode == code {
+					return true
+				}
+			}
+		}
+		return IsConnectionFailure(err)
+	}
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// wait sleeps before the given retry, returning early with an error if ctx is
+// done first.
+func (p *RetryPolicy) wait(ctx context.Context, retry int) error {
+	if p.Backoff == nil {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(p.Backoff(retry))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryPolicy returns the retry policy for a statement run with ctx: the one
+// of a VerticaContext, or failing that the one of the connector.
+func (s *stmt) retryPolicy(ctx context.Context) *RetryPolicy {
+	policy := s.conn.config.RetryPolicy
+	if vCtx, ok := ctx.(VerticaContext); ok && vCtx.GetRetryPolicy() != nil {
+		policy = vCtx.GetRetryPolicy()
+	}
+	if policy == nil || policy.MaxAttempts < 2 {
+		return nil
+	}
+	return policy
+}
+
+// safeToRetry reports whether the statement can be run again after it failed:
+// nothing it did outlives the failure, and running it twice does no harm.
+func (s *stmt) safeToRetry(ctx context.Context) bool {
+	// A connection already given up on is left for database/sql to discard.
+	if s.conn.dead || s.conn.TransactionStatus() != TransactionIdle {
+		return false
+	}
+	if vCtx, ok := ctx.(VerticaContext); ok && vCtx.GetIdempotent() {
+		return true
+	}
+	return s.conn.autocommit == "on" && isPlainSelect(s.command)
+}
+
+// queryWithRetry runs the statement, running it again as the policy allows
+// while it fails with a retryable error.
+func (s *stmt) queryWithRetry(ctx context.Context, args []driver.NamedValue, policy *RetryPolicy) (driver.Rows, error) {
+	for attempt := 1; ; attempt++ {
+		rows, err := s.QueryContextRaw(ctx, args)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return rows, err
+		}
+		stmtLogger.Info("retrying statement after attempt %d failed: %v", attempt, err)
+		if waitErr := policy.wait(ctx, attempt); waitErr != nil {
+			return rows, err
+		}
+		if s.conn.dead || IsConnectionFailure(err) {
+			// The statement's session is lost.
+			s.rolledBack = false
+			if connErr := s.conn.reconnect(); connErr != nil {
+				stmtLogger.Warn("unable to reconnect for a retry after: %v", err)
+				return rows, connErr
+			}
+		} else if s.rolledBack {
+			// The session survives a ROLLBACK, but the prepared statement may
+			// not, so prepare it again under a new name.
+			s.rolledBack = false
+			if s.parseState == parseStateParsed {
+				s.preparedName = newPreparedName()
+				s.parseState = parseStateUnparsed
+				if prepErr := s.prepareAndDescribe(); prepErr != nil {
+					return rows, prepErr
+				}
+			}
+		}
+	}
+}
+
+// isPlainSelect reports whether command is a single SELECT that only reads
+// data: it has a FROM clause of its own, writes no table with INTO and draws
+// no sequence values. A SELECT without FROM is most often a call to a function
+// such as CLOSE_SESSION or PURGE_TABLE, which can have side effects.
+func isPlainSelect(command string) bool {
+	statements := parse.SplitStatements(command)
+	if len(statements) != 1 {
+		return false
+	}
+	tokens := topLevelSQLTokens(statements[0])
+	if len(tokens) == 0 || tokens[0].text != "SELECT" {
+		return false
+	}
+	hasFrom := false
+	for _, token := range tokens[1:] {
+		switch token.text {
+		case "INTO", "NEXTVAL":
+			return false
+		case "FROM":
+			hasFrom = hasFrom || token.depth == tokens[0].depth
+		}
+	}
+	return hasFrom
+}
@@ -0,0 +1,235 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		800 * time.Millisecond, time.Second, time.Second}
+	for idx, wait := range want {
+		assertEqual(t, backoff(idx+1), wait)
+	}
+}
+
+func TestRetrySQLStates(t *testing.T) {
+	retryable := RetrySQLStates(SQLStateLockTimeout, ErrorCodeDuplicateKey)
+
+	if !retryable(&VError{SQLState: SQLStateLockTimeout}) {
+		t.Error("expected a lock timeout to be retried")
+	}
+	if !retryable(&VError{SQLState: SQLStateUniqueViolation, ErrorCode: ErrorCodeDuplicateKey}) {
+		t.Error("expected the error code to be matched")
+	}
+	if !retryable(io.EOF) {
+		t.Error("expected a lost connection to be retried")
+	}
+	if retryable(&VError{SQLState: SQLStateDeadlock}) {
+		t.Error("did not expect a deadlock to be retried")
+	}
+
+	policy := &RetryPolicy{MaxAttempts: 3}
+	if !policy.retryable(&VError{SQLState: SQLStateDeadlock}) || policy.retryable(&VError{SQLState: SQLStateSyntaxError}) {
+		t.Error("expected a policy without a classifier to use IsRetryable")
+	}
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, Backoff: func(int) time.Duration { return time.Hour }}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := policy.wait(canceled, 1); err != context.Canceled {
+		t.Errorf("expected the wait to end with the context, got %v", err)
+	}
+	assertNoErr(t, (&RetryPolicy{MaxAttempts: 3}).wait(context.Background(), 1))
+}
+
+func TestStmtRetry(t *testing.T) {
+	configPolicy := &RetryPolicy{MaxAttempts: 3}
+	conn := &connection{
+		config:           Config{RetryPolicy: configPolicy},
+		autocommit:       "on",
+		transactionState: byte(TransactionIdle),
+	}
+	stmt := testStatement("SELECT * FROM t WHERE a = ?")
+	stmt.conn = conn
+	vCtx := NewVerticaContext(context.Background())
+
+	if stmt.retryPolicy(context.Background()) != configPolicy {
+		t.Error("expected the connector's policy")
+	}
+	ctxPolicy := &RetryPolicy{MaxAttempts: 5}
+	assertNoErr(t, vCtx.SetRetryPolicy(ctxPolicy))
+	if stmt.retryPolicy(vCtx) != ctxPolicy {
+		t.Error("expected the context's policy to take precedence")
+	}
+	assertNoErr(t, vCtx.SetRetryPolicy(&RetryPolicy{MaxAttempts: 1}))
+	if stmt.retryPolicy(vCtx) != nil {
+		t.Error("expected a single attempt to turn retries off")
+	}
+
+	tests := []struct {
+		command    string
+		autocommit string
+		state      TransactionStatus
+		idempotent bool
+		safe       bool
+	}{
+		{"SELECT * FROM t WHERE a = ?", "on", TransactionIdle, false, true},
+		{"  (select a from t) UNION (SELECT b FROM u)", "on", TransactionIdle, false, true},
+		{"SELECT a FROM t", "off", TransactionIdle, false, false},
+		{"SELECT a FROM t", "on", TransactionActive, false, false},
+		{"SELECT a FROM t; SELECT b FROM u", "on", TransactionIdle, false, false},
+		{"SELECTED a FROM t", "on", TransactionIdle, false, false},
+		{"SELECT 1", "on", TransactionIdle, false, false},
+		{"SELECT CLOSE_SESSION('s1')", "on", TransactionIdle, false, false},
+		{"SELECT PURGE_TABLE((SELECT MIN(table_name) FROM tables))", "on", TransactionIdle, false, false},
+		{"SELECT a INTO TEMP TABLE c FROM t", "on", TransactionIdle, false, false},
+		{"SELECT NEXTVAL('s'), a FROM t", "on", TransactionIdle, false, false},
+		{"SELECT 'no INTO here' FROM t -- nor into", "on", TransactionIdle, false, true},
+		{"SELECT CLOSE_SESSION('s1')", "on", TransactionIdle, true, true},
+		{"INSERT INTO t VALUES (1)", "on", TransactionIdle, false, false},
+		{"INSERT INTO t VALUES (1)", "off", TransactionIdle, true, true},
+		{"INSERT INTO t VALUES (1)", "off", TransactionFailed, true, false},
+	}
+	for _, tc := range tests {
+		stmt.command = tc.command
+		conn.autocommit = tc.autocommit
+		conn.transactionState = byte(tc.state)
+		assertNoErr(t, vCtx.SetIdempotent(tc.idempotent))
+		if got := stmt.safeToRetry(vCtx); got != tc.safe {
+			t.Errorf("safeToRetry(%q, autocommit %s, %v, idempotent %v) = %v", tc.command, tc.autocommit,
+				tc.state, tc.idempotent, got)
+		}
+	}
+}
+
+// brokenConn is a socket the server has hung up on.
+type brokenConn struct {
+	net.Conn
+}
+
+func (brokenConn) Write([]byte) (int, error) {
+	return 0, &net.OpError{Op: "write", Net: "tcp", Err: syscall.EPIPE}
+}
+
+func TestReconnectFailure(t *testing.T) {
+	// The server hangs up on every new connection, so the dial succeeds and
+	// the handshake fails.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assertNoErr(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			sock, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			sock.Close()
+		}
+	}()
+
+	client, server := net.Pipe()
+	defer server.Close()
+	conn := &connection{
+		conn:             brokenConn{client},
+		connHostsList:    []string{listener.Addr().String()},
+		config:           Config{User: "dbadmin", RetryPolicy: &RetryPolicy{MaxAttempts: 3}},
+		autocommit:       "on",
+		transactionState: byte(TransactionIdle),
+	}
+	stmt := testStatement("SELECT a FROM t")
+	stmt.conn = conn
+
+	// The lost session fails the statement, and as no new one can be started
+	// the connection is given up.
+	_, err = stmt.QueryContext(context.Background(), nil)
+	if err != driver.ErrBadConn {
+		t.Fatalf("expected driver.ErrBadConn, got %v", err)
+	}
+	if !conn.dead || conn.conn != nil {
+		t.Errorf("expected a dead connection without a socket, got dead=%v conn=%v", conn.dead, conn.conn)
+	}
+	if err = conn.ResetSession(context.Background()); err != driver.ErrBadConn {
+		t.Errorf("expected ResetSession to report driver.ErrBadConn, got %v", err)
+	}
+	if err = conn.Ping(context.Background()); err != driver.ErrBadConn {
+		t.Errorf("expected Ping to report driver.ErrBadConn, got %v", err)
+	}
+	assertNoErr(t, conn.Close())
+}
+
+func TestRetryKeepsSessionAfterLockTimeout(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	conn := &connection{
+		conn:             client,
+		config:           Config{RetryPolicy: &RetryPolicy{MaxAttempts: 3}},
+		autocommit:       "on",
+		transactionState: byte(TransactionIdle),
+	}
+	stmt := testStatement("SELECT a FROM t")
+	stmt.conn = conn
+
+	go func() {
+		// The first attempt times out on a lock, the second succeeds, both
+		// over the same socket.
+		readFrontEndMsg(t, server)
+		writeBackEndMsg(t, server, 'E', []byte("SROLLBACK\x00C"+SQLStateLockTimeout+"\x00MLocking failure\x00\x00"))
+		writeBackEndMsg(t, server, 'Z', []byte("I"))
+		readFrontEndMsg(t, server)
+		writeBackEndMsg(t, server, 'C', []byte("SELECT 0\x00"))
+		writeBackEndMsg(t, server, 'Z', []byte("I"))
+	}()
+
+	rows, err := stmt.QueryContext(context.Background(), nil)
+	assertNoErr(t, err)
+	assertNoErr(t, rows.Close())
+	if conn.generation != 0 || conn.conn != client || conn.dead {
+		t.Errorf("expected the retry to keep the session, got generation %d, dead %v", conn.generation, conn.dead)
+	}
+	if stmt.rolledBack {
+		t.Error("expected the retry to clear the rollback")
+	}
+}
@@ -59,11 +59,24 @@ type rowStore interface {
 }
 
 type rows struct {
-	columnDefs *msgs.BERowDescMsg
-	resultData rowStore
-
-	tzOffset      string
-	inMemRowLimit int
+	columnDefs   *msgs.BERowDescMsg
+	complexTypes []*common.ComplexType // per column; nil for scalar columns, see complexType
+	types        *TypeRegistry         // registered decoders; nil uses the global ones
+	decoders     []*typeDecoder        // per column; nil for built-in decoding, see typeDecoder
+	resultData   rowStore
+	stream       *rowStream // non-nil when resultData reads rows from the wire
+	commandTag   string     // the server's tag for the statement, such as "INSERT 0 5"
+	statement    string     // the SQL of the statement that produced the rows
+	notices      []*Notice  // sent by the server while the statement ran
+
+	location        *time.Location // the session's time zone; nil is UTC
+	inMemRowLimit   int
+	exactNumeric    bool            // NUMERIC columns are returned as Decimal
+	typedIntervals  bool            // INTERVAL columns are returned as Interval
+	naiveTimestamps bool            // TIMESTAMP columns are returned in UTC
+	typedTime       bool            // TIME and TIMETZ columns are returned as Time and TimeTZ
+	lenient         bool            // values that fail to decode become NULL instead of stopping Next
+	decodeErrs      *decodeErrorLog // where lenient rows record their decode errors, if anywhere
 }
 
 var (
@@ -90,13 +103,21 @@ func (r *rows) Close() error {
 }
 
 func (r *rows) Next(dest []driver.Value) error {
-	var err error
 	nextRow := r.resultData.GetRow()
 	if nextRow == nil {
+		if r.stream != nil && r.stream.Err() != nil {
+			return r.stream.Err()
+		}
 		return io.EOF
 	}
 
 	rowCols := nextRow.Columns()
+	if int(rowCols.NumCols) > len(r.columnDefs.Columns) {
+		return fmt.Errorf("row has %d columns but the result describes %d", rowCols.NumCols, len(r.columnDefs.Columns))
+	}
+	if int(rowCols.NumCols) > len(dest) {
+		return fmt.Errorf("row has %d columns but only %d destination values were given", rowCols.NumCols, len(dest))
+	}
 
 	for idx := uint16(0); idx < rowCols.NumCols; idx++ {
 		colVal := rowCols.Chunk()
@@ -105,62 +126,181 @@ func (r *rows) Next(dest []driver.Value) error {
 			continue
 		}
 
-		switch r.columnDefs.Columns[idx].DataTypeOID {
-		case common.ColTypeBoolean: // to boolean
-			dest[idx] = colVal[0] == 't'
-		case common.ColTypeInt64: // to integer
-			dest[idx], err = strconv.Atoi(string(colVal))
-		case common.ColTypeVarChar, common.ColTypeLongVarChar, common.ColTypeChar, common.ColTypeUUID: // stays string, convert char to string
-			dest[idx] = string(colVal)
-		case common.ColTypeFloat64, common.ColTypeNumeric: // to float64
-			dest[idx], err = strconv.ParseFloat(string(colVal), 64)
-		case common.ColTypeDate: // to time.Time from YYYY-MM-DD
-			dest[idx], err = parseDateColumn(string(colVal))
-		case common.ColTypeTimestamp: // to time.Time from YYYY-MM-DD hh:mm:ss
-			dest[idx], err = parseTimestampTZColumn(string(colVal) + r.tzOffset)
-		case common.ColTypeTimestampTZ:
-			dest[idx], err = parseTimestampTZColumn(string(colVal))
-		case common.ColTypeTime: // to time.Time from hh:mm:ss.[fff...]
-			dest[idx], err = parseTimestampTZColumn("0000-01-01 " + string(colVal) + r.tzOffset)
-		case common.ColTypeTimeTZ:
-			dest[idx], err = parseTimestampTZColumn("0000-01-01 " + string(colVal))
-		case common.ColTypeInterval, common.ColTypeIntervalYM: // stays string
-			dest[idx] = string(colVal)
-		case common.ColTypeVarBinary, common.ColTypeLongVarBinary, common.ColTypeBinary:
-			// to []byte; convert escaped octal (e.g. \261) into byte with \\ for \
-			var out []byte
-			for len(colVal) > 0 {
-				c := colVal[0]
-				if c == '\\' {
-					if colVal[1] == '\\' { // escaped \
-						colVal = colVal[2:]
-					} else { // A \xxx octal string
-						x, _ := strconv.ParseInt(string(colVal[1:4]), 8, 32)
-						c = byte(x)
-						colVal = colVal[4:]
-					}
-				} else {
-					colVal = colVal[1:]
-				}
-				out = append(out, c)
+		value, err := r.decodeColumn(int(idx), colVal)
+		if err != nil {
+			col := r.columnDefs.Columns[idx]
+			decodeErr := &DecodeError{
+				Column:     int(idx),
+				ColumnName: col.FieldName,
+				TypeOID:    col.DataTypeOID,
+				Raw:        append([]byte(nil), colVal...),
+				Err:        err,
 			}
-			dest[idx] = out
-		default:
-			dest[idx] = string(colVal)
+			if !r.lenient {
+				return decodeErr
+			}
+			rowLogger.Warn("%s", decodeErr.Error())
+			if r.decodeErrs != nil {
+				r.decodeErrs.add(decodeErr)
+			}
+			value = nil
 		}
+		dest[idx] = value
+	}
+
+	return nil
+}
 
+// decodeColumn converts the value of column idx into its Go value.
+func (r *rows) decodeColumn(idx int, colVal []byte) (driver.Value, error) {
+	if dec := r.typeDecoder(idx); dec != nil {
+		return dec.decode(colVal)
+	}
+	if t := r.complexType(idx); t != nil {
+		return r.decodeComplex(t, colVal)
+	}
+	col := r.columnDefs.Columns[idx]
+	if col.FormatCode == formatBinary {
+		return r.decodeBinary(col, colVal)
+	}
+	return r.decodeScalar(col.DataTypeOID, col.DataTypeMod, colVal)
+}
+
+// typeDecoder returns the registered decoder of column idx, or nil. Decoders
+// are looked up once, and again if the columns are expanded.
+func (r *rows) typeDecoder(idx int) *typeDecoder {
+	if len(r.decoders) != len(r.columnDefs.Columns) {
+		r.decoders = make([]*typeDecoder, len(r.columnDefs.Columns))
+		for colIdx, col := range r.columnDefs.Columns {
+			r.decoders[colIdx] = r.types.lookupDecoder(col)
+		}
+	}
+	return r.decoders[idx]
+}
+
+// complexType returns the structure of column idx, or nil for scalar columns.
+// Column types are parsed once, and again if the columns are expanded.
+func (r *rows) complexType(idx int) *common.ComplexType {
+	if len(r.complexTypes) != len(r.columnDefs.Columns) {
+		r.complexTypes = make([]*common.ComplexType, len(r.columnDefs.Columns))
+		for colIdx, col := range r.columnDefs.Columns {
+			r.complexTypes[colIdx] = common.ParseComplexType(col.DataTypeOID, col.DataTypeName)
+		}
+	}
+	return r.complexTypes[idx]
+}
+
+// decodeScalar converts the text form of a scalar type into its Go value. It is
+// used for columns and for the elements of complex values alike.
+func (r *rows) decodeScalar(typeOID uint32, typeMod int32, colVal []byte) (driver.Value, error) {
+	switch typeOID {
+	case common.ColTypeBoolean: // to boolean
+		return colVal[0] == 't', nil
+	case common.ColTypeInt64: // to integer
+		return strconv.Atoi(string(colVal))
+	case common.ColTypeVarChar, common.ColTypeLongVarChar, common.ColTypeChar, common.ColTypeUUID: // stays string, convert char to string
+		return string(colVal), nil
+	case common.ColTypeFloat64: // to float64
+		return strconv.ParseFloat(string(colVal), 64)
+	case common.ColTypeNumeric: // to float64, or Decimal to keep every digit
+		if r.exactNumeric {
+			return Decimal(colVal), nil
+		}
+		return strconv.ParseFloat(string(colVal), 64)
+	case common.ColTypeDate: // to time.Time from YYYY-MM-DD
+		return parseDateColumn(string(colVal))
+	case common.ColTypeTimestamp: // to time.Time from YYYY-MM-DD hh:mm:ss, in the session's time zone
+		t, err := parseTimestampTZColumn(string(colVal) + "+00")
+		if err != nil {
+			return t, err
+		}
+		return r.timestampInZone(t.(time.Time)), nil
+	case common.ColTypeTimestampTZ:
+		return parseTimestampTZColumn(string(colVal))
+	case common.ColTypeTime: // to time.Time from hh:mm:ss.[fff...], or Time when typed
+		if r.typedTime {
+			return parseTime(string(colVal))
+		}
+		t, err := parseTimestampTZColumn("0000-01-01 " + string(colVal) + "+00")
 		if err != nil {
-			rowLogger.Error("%s", err.Error())
+			return t, err
+		}
+		return wallClockIn(t.(time.Time), r.timeOfDayLocation()), nil
+	case common.ColTypeTimeTZ: // to time.Time, or TimeTZ when typed
+		if r.typedTime {
+			return parseTimeTZ(string(colVal))
 		}
+		return parseTimestampTZColumn("0000-01-01 " + string(colVal))
+	case common.ColTypeInterval, common.ColTypeIntervalYM: // stays string, or Interval when typed
+		if r.typedIntervals {
+			return parseInterval(string(colVal), common.IntervalRange(typeOID, typeMod))
+		}
+		return string(colVal), nil
+	case common.ColTypeVarBinary, common.ColTypeLongVarBinary, common.ColTypeBinary:
+		// to []byte; convert escaped octal (e.g. \261) into byte with \\ for \
+		var out []byte
+		for len(colVal) > 0 {
+			c := colVal[0]
+			if c == '\\' {
+				if colVal[1] == '\\' { // escaped \
+					colVal = colVal[2:]
+				} else { // A \xxx octal string
+					x, _ := strconv.ParseInt(string(colVal[1:4]), 8, 32)
+					c = byte(x)
+					colVal = colVal[4:]
+				}
+			} else {
+				colVal = colVal[1:]
+			}
+			out = append(out, c)
+		}
+		return out, nil
+	default:
+		return string(colVal), nil
+	}
+}
+
+// timestampInZone gives the wall clock t of a TIMESTAMP the offset the
+// session's time zone has at that date, so values on either side of a DST
+// change decode to the right instant. With naive timestamps it stays in UTC.
+func (r *rows) timestampInZone(t time.Time) time.Time {
+	if _, ok := infinityText(t); ok {
+		return t
+	}
+	if r.naiveTimestamps {
+		return wallClockIn(t, time.UTC)
+	}
+	return wallClockIn(t, r.serverLocation())
+}
+
+// serverLocation is the session's time zone.
+func (r *rows) serverLocation() *time.Location {
+	if r.location == nil {
+		return time.UTC
 	}
+	return r.location
+}
 
-	return err
+// timeOfDayLocation is the zone of TIME values. They have no date to look the
+// offset up for, so the current offset of the session's time zone is used.
+func (r *rows) timeOfDayLocation() *time.Location {
+	name, offset := time.Now().In(r.serverLocation()).Zone()
+	return time.FixedZone(name, offset)
+}
+
+// wallClockIn returns the time with the wall clock of t in loc.
+func wallClockIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
 }
 
 func parseDateColumn(fullString string) (driver.Value, error) {
 	var result driver.Value
 	var err error
 
+	if t, ok := parseInfinity(fullString); ok {
+		return t, nil
+	}
+
 	// Dates Before Christ (YYYY-MM-DD BC) are special
 	if strings.HasSuffix(fullString, " BC") {
 		var t time.Time
@@ -181,8 +321,8 @@ func parseTimestampTZColumn(fullString string) (driver.Value, error) {
 	var isBC bool
 
 	// +infinity or -infinity value
-	if strings.Contains(fullString, "infinity") {
-		return time.Time{}, fmt.Errorf("cannot parse an infinity timestamp to time.Time")
+	if t, ok := parseInfinity(fullString); ok {
+		return t, nil
 	}
 
 	if isBC = strings.Contains(fullString, " BC"); isBC {
@@ -220,7 +360,44 @@ func (r *rows) addRow(rowData *msgs.BEDataRowMsg) error {
 	return r.resultData.AddRow(rowData)
 }
 
-func newRows(ctx context.Context, columnsDefsMsg *msgs.BERowDescMsg, tzOffset string) *rows {
+// startStream switches the result over to reading the remaining rows from the
+// wire, starting with firstRow.
+func (r *rows) startStream(ctx context.Context, s *stmt, firstRow *msgs.BEDataRowMsg, simpleQuery bool) {
+	_ = r.resultData.Close()
+	r.notices = s.conn.takeNotices()
+	r.stream = newRowStream(ctx, s, firstRow, simpleQuery)
+	r.resultData = r.stream
+}
+
+// attachStream hands the session lock to a streamed result, which calls release
+// once the server has finished sending it. It returns false if there is nothing
+// left to stream, in which case the caller keeps ownership of the lock.
+func (r *rows) attachStream(release func()) bool {
+	if r.stream == nil {
+		return false
+	}
+	return r.stream.attach(release)
+}
+
+// tag returns the command tag of the statement, which a streamed result only
+// knows once the last row has been read.
+func (r *rows) tag() string {
+	if r.stream != nil && r.stream.tag != "" {
+		return r.stream.tag
+	}
+	return r.commandTag
+}
+
+// Notices returns the notices the server sent while the statement ran. A
+// streamed result only has all of them once the last row has been read.
+func (r *rows) Notices() []*Notice {
+	if r.stream != nil && len(r.stream.notices) > 0 {
+		return append(append([]*Notice(nil), r.notices...), r.stream.notices...)
+	}
+	return r.notices
+}
+
+func newRows(ctx context.Context, columnsDefsMsg *msgs.BERowDescMsg, location *time.Location) *rows {
 
 	rowBufferSize := defaultRowBufferSize
 	inMemRowLimit := 0
@@ -243,7 +420,7 @@ func newRows(ctx context.Context, columnsDefsMsg *msgs.BERowDescMsg, tzOffset st
 	res := &rows{
 		columnDefs:    columnsDefsMsg,
 		resultData:    resultData,
-		tzOffset:      tzOffset,
+		location:      location,
 		inMemRowLimit: inMemRowLimit,
 	}
 
@@ -328,19 +505,54 @@ func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
 // Returns the value type that can be used to scan types into.
 // Interface: driver.RowsColumnTypeScanType
 func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if dec := r.typeDecoder(index); dec != nil {
+		if dec.scanType != nil {
+			return dec.scanType
+		}
+		return reflect.TypeOf(new(interface{}))
+	}
+	if t := r.complexType(index); t != nil {
+		switch t.Kind {
+		case common.KindRow:
+			return reflect.TypeOf(Row{})
+		case common.KindMap:
+			return reflect.TypeOf(map[string]interface{}(nil))
+		default:
+			return reflect.TypeOf(Array(nil))
+		}
+	}
 	switch r.columnDefs.Columns[index].DataTypeOID {
 	case common.ColTypeBoolean:
 		return reflect.TypeOf(sql.NullBool{})
 	case common.ColTypeInt64:
 		return reflect.TypeOf(sql.NullInt64{})
-	case common.ColTypeFloat64, common.ColTypeNumeric:
+	case common.ColTypeFloat64:
 		return reflect.TypeOf(sql.NullFloat64{})
+	case common.ColTypeNumeric:
+		if r.exactNumeric {
+			return reflect.TypeOf(NullDecimal{})
+		}
+		return reflect.TypeOf(sql.NullFloat64{})
+	case common.ColTypeInterval, common.ColTypeIntervalYM:
+		if r.typedIntervals {
+			return reflect.TypeOf(NullInterval{})
+		}
+		return reflect.TypeOf(sql.NullString{})
 	case common.ColTypeVarChar, common.ColTypeLongVarChar, common.ColTypeChar,
 		common.ColTypeVarBinary, common.ColTypeLongVarBinary, common.ColTypeBinary,
-		common.ColTypeUUID, common.ColTypeInterval, common.ColTypeIntervalYM:
+		common.ColTypeUUID:
 		return reflect.TypeOf(sql.NullString{})
-	case common.ColTypeDate, common.ColTypeTimestamp, common.ColTypeTimestampTZ,
-		common.ColTypeTime, common.ColTypeTimeTZ:
+	case common.ColTypeTime:
+		if r.typedTime {
+			return reflect.TypeOf(NullTime{})
+		}
+		return reflect.TypeOf(sql.NullTime{})
+	case common.ColTypeTimeTZ:
+		if r.typedTime {
+			return reflect.TypeOf(NullTimeTZ{})
+		}
+		return reflect.TypeOf(sql.NullTime{})
+	case common.ColTypeDate, common.ColTypeTimestamp, common.ColTypeTimestampTZ:
 		return reflect.TypeOf(sql.NullTime{})
 	default:
 		return reflect.TypeOf(new(interface{}))
@@ -350,7 +562,7 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 func newEmptyRows() *rows {
 	cdf := make([]*msgs.BERowDescColumnDef, 0)
 	be := &msgs.BERowDescMsg{Columns: cdf}
-	return newRows(context.Background(), be, "")
+	return newRows(context.Background(), be, nil)
 }
 
 // expandColumnDefs grows r.columnDefs to cover at least numCols columns.
@@ -362,12 +574,11 @@ func newEmptyRows() *rows {
 // The caller invokes this whenever a DataRow wider than columnDefs is seen,
 // not only on the first row.
 //
-// Ordering guarantee: this function is called inside collectResults(), which
-// fully drains the wire and buffers all rows before returning the *rows object
-// to database/sql. Because database/sql only calls Columns() after receiving
-// that object, all expansions are complete before Columns() executes.
-// This invariant would break in a streaming/lazy-row model where *rows is
-// handed to the caller before all DataRows have been received.
+// Ordering guarantee: this function is called inside collectResults(), before
+// the *rows object is returned to database/sql. A buffered result has read every
+// DataRow by then. A streamed result is returned on its first DataRow, so only
+// that row is used to widen the columns; rows.Next returns an error for any later
+// row wider than the columns already reported by Columns().
 func (r *rows) expandColumnDefs(numCols uint16) {
 	for uint16(len(r.columnDefs.Columns)) < numCols {
 		r.columnDefs.Columns = append(r.columnDefs.Columns, &msgs.BERowDescColumnDef{
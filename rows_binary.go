@@ -0,0 +1,230 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+// Result format codes requested in a Bind message.
+const (
+	formatText   uint16 = 0
+	formatBinary uint16 = 1
+)
+
+// Binary dates and timestamps count from the start of 2000.
+var binaryEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// binaryResultSupported reports whether columns of typeOID can be decoded from
+// the binary result format.
+func binaryResultSupported(typeOID uint32) bool {
+	switch typeOID {
+	case common.ColTypeBoolean, common.ColTypeInt64, common.ColTypeFloat64,
+		common.ColTypeDate, common.ColTypeTime, common.ColTypeTimestamp, common.ColTypeTimestampTZ,
+		common.ColTypeNumeric, common.ColTypeVarBinary, common.ColTypeLongVarBinary, common.ColTypeBinary:
+		return true
+	}
+	return false
+}
+
+// binaryResultFormats returns a result format code for each column of desc,
+// binary where a decoder exists and text otherwise. It returns nil when no
+// column can use binary, so the Bind message asks for text throughout.
+func binaryResultFormats(desc *msgs.BERowDescMsg) []uint16 {
+	formats := make([]uint16, len(desc.Columns))
+	anyBinary := false
+	for idx, col := range desc.Columns {
+		if binaryResultSupported(col.DataTypeOID) {
+			formats[idx] = formatBinary
+			anyBinary = true
+		}
+	}
+	if !anyBinary {
+		return nil
+	}
+	return formats
+}
+
+// withResultFormats returns a copy of desc whose columns carry the format codes
+// requested for them. A statement's Describe reports every column as text.
+func withResultFormats(desc *msgs.BERowDescMsg, formats []uint16) *msgs.BERowDescMsg {
+	if formats == nil {
+		return desc
+	}
+	res := &msgs.BERowDescMsg{Columns: make([]*msgs.BERowDescColumnDef, len(desc.Columns))}
+	for idx, col := range desc.Columns {
+		colCopy := *col
+		if idx < len(formats) {
+			colCopy.FormatCode = formats[idx]
+		}
+		res.Columns[idx] = &colCopy
+	}
+	return res
+}
+
+// decodeBinary converts a column sent in the binary result format into the
+// same Go value its text form decodes to.
+func (r *rows) decodeBinary(col *msgs.BERowDescColumnDef, colVal []byte) (driver.Value, error) {
+	switch col.DataTypeOID {
+	case common.ColTypeBoolean:
+		if len(colVal) != 1 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		return colVal[0] != 0, nil
+	case common.ColTypeInt64:
+		if len(colVal) != 8 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		return int(int64(binary.BigEndian.Uint64(colVal))), nil
+	case common.ColTypeFloat64:
+		if len(colVal) != 8 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(colVal)), nil
+	case common.ColTypeNumeric:
+		text := binaryNumericText(colVal, numericScale(col.DataTypeMod))
+		if r.exactNumeric {
+			return Decimal(text), nil
+		}
+		return strconv.ParseFloat(text, 64)
+	case common.ColTypeDate:
+		if len(colVal) != 8 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		days := int64(binary.BigEndian.Uint64(colVal))
+		if t, ok := binaryInfinity(days); ok {
+			return t, nil
+		}
+		return binaryEpoch.AddDate(0, 0, int(days)), nil
+	case common.ColTypeTimestamp:
+		if len(colVal) != 8 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		if t, ok := binaryInfinity(int64(binary.BigEndian.Uint64(colVal))); ok {
+			return t, nil
+		}
+		// The wall clock of a TIMESTAMP is read in the session's time zone,
+		// as the text form is.
+		return r.timestampInZone(binaryTimestamp(colVal)), nil
+	case common.ColTypeTimestampTZ:
+		if len(colVal) != 8 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		if t, ok := binaryInfinity(int64(binary.BigEndian.Uint64(colVal))); ok {
+			return t, nil
+		}
+		return binaryTimestamp(colVal).In(r.serverLocation()), nil
+	case common.ColTypeTime:
+		if len(colVal) != 8 {
+			return nil, binaryLengthError(col, colVal)
+		}
+		micros := time.Duration(int64(binary.BigEndian.Uint64(colVal))) * time.Microsecond
+		if r.typedTime {
+			return timeSinceMidnight(micros), nil
+		}
+		return time.Date(0, 1, 1, 0, 0, 0, 0, r.timeOfDayLocation()).Add(micros), nil
+	case common.ColTypeVarBinary, common.ColTypeLongVarBinary, common.ColTypeBinary:
+		out := make([]byte, len(colVal))
+		copy(out, colVal)
+		return out, nil
+	}
+
+	// Anything else was not requested as binary; read it as text.
+	return r.decodeScalar(col.DataTypeOID, col.DataTypeMod, colVal)
+}
+
+func binaryLengthError(col *msgs.BERowDescColumnDef, colVal []byte) error {
+	return fmt.Errorf("unexpected %d byte binary value for %s column %q", len(colVal), col.DataTypeName, col.FieldName)
+}
+
+// binaryInfinity returns the sentinel for the largest and smallest binary DATE
+// and timestamp values, which the server sends for infinity.
+func binaryInfinity(v int64) (time.Time, bool) {
+	switch v {
+	case math.MaxInt64:
+		return PositiveInfinity, true
+	case math.MinInt64:
+		return NegativeInfinity, true
+	}
+	return time.Time{}, false
+}
+
+// binaryTimestamp reads a big-endian count of microseconds since the epoch.
+// Whole days are added separately, as the span of a time.Duration is too
+// short for the full range of Vertica timestamps.
+func binaryTimestamp(colVal []byte) time.Time {
+	micros := int64(binary.BigEndian.Uint64(colVal))
+	days := micros / microsPerDay
+	return binaryEpoch.AddDate(0, 0, int(days)).Add(time.Duration(micros-days*microsPerDay) * time.Microsecond)
+}
+
+// numericScale returns the scale of a NUMERIC type modifier, using the default
+// scale when the modifier is unknown.
+func numericScale(typeMod int32) int {
+	if typeMod == -1 {
+		return 15
+	}
+	return int((typeMod - 4) & 0xFF)
+}
+
+// binaryNumericText formats a NUMERIC sent as a big-endian two's complement
+// unscaled integer, e.g. 1234 with scale 2 as "12.34".
+func binaryNumericText(colVal []byte, scale int) string {
+	unscaled := new(big.Int).SetBytes(colVal)
+	if len(colVal) > 0 && colVal[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(colVal)*8)))
+	}
+
+	digits := unscaled.String()
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
@@ -0,0 +1,197 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func int64Bytes(v int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func TestDecodeBinaryMatchesText(t *testing.T) {
+	r := &rows{location: time.FixedZone("", -5*3600)}
+	numeric := (int32(20)<<16 | 4) + 4 // NUMERIC(20,4)
+
+	testCases := []struct {
+		name    string
+		typeOID uint32
+		typeMod int32
+		binary  []byte
+		text    string
+	}{
+		{name: "true", typeOID: common.ColTypeBoolean, binary: []byte{1}, text: "t"},
+		{name: "false", typeOID: common.ColTypeBoolean, binary: []byte{0}, text: "f"},
+		{name: "int", typeOID: common.ColTypeInt64, binary: int64Bytes(-1234567890123), text: "-1234567890123"},
+		{name: "float", typeOID: common.ColTypeFloat64, binary: int64Bytes(int64(math.Float64bits(-2.5e-10))), text: "-2.5e-10"},
+		{name: "numeric", typeOID: common.ColTypeNumeric, typeMod: numeric, binary: append(make([]byte, 8), int64Bytes(123456)...), text: "12.3456"},
+		{name: "negative numeric", typeOID: common.ColTypeNumeric, typeMod: numeric, binary: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfb}, text: "-0.0005"},
+		{name: "date", typeOID: common.ColTypeDate, binary: int64Bytes(8825), text: "2024-02-29"},
+		{name: "date before epoch", typeOID: common.ColTypeDate, binary: int64Bytes(-730119), text: "0001-01-01"},
+		{name: "timestamp", typeOID: common.ColTypeTimestamp, binary: int64Bytes(8825*microsPerDay + 13*microsPerHour + 500), text: "2024-02-29 13:00:00.0005"},
+		{name: "timestamptz", typeOID: common.ColTypeTimestampTZ, binary: int64Bytes(8825*microsPerDay + 13*microsPerHour), text: "2024-02-29 08:00:00-05"},
+		{name: "far timestamp", typeOID: common.ColTypeTimestamp, binary: int64Bytes(2914635 * microsPerDay), text: "9980-01-01 00:00:00"},
+		{name: "time", typeOID: common.ColTypeTime, binary: int64Bytes(13*microsPerHour + 1), text: "13:00:00.000001"},
+		{name: "varbinary", typeOID: common.ColTypeVarBinary, binary: []byte{0, '\\', 0xff}, text: `\000\\\377`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			col := &msgs.BERowDescColumnDef{FieldName: "c", DataTypeOID: tc.typeOID, DataTypeMod: tc.typeMod, FormatCode: formatBinary}
+			fromBinary, err := r.decodeBinary(col, tc.binary)
+			if err != nil {
+				t.Fatalf("unexpected binary error: %v", err)
+			}
+			fromText, err := r.decodeScalar(tc.typeOID, tc.typeMod, []byte(tc.text))
+			if err != nil {
+				t.Fatalf("unexpected text error: %v", err)
+			}
+			if tb, ok := fromBinary.(time.Time); ok {
+				tt := fromText.(time.Time)
+				_, binOffset := tb.Zone()
+				_, textOffset := tt.Zone()
+				if !tb.Equal(tt) || binOffset != textOffset {
+					t.Errorf("expected %v, got %v", tt, tb)
+				}
+				return
+			}
+			if !reflect.DeepEqual(fromBinary, fromText) {
+				t.Errorf("expected %T(%v), got %T(%v)", fromText, fromText, fromBinary, fromBinary)
+			}
+		})
+	}
+
+	r.exactNumeric = true
+	col := &msgs.BERowDescColumnDef{DataTypeOID: common.ColTypeNumeric, DataTypeMod: numeric, FormatCode: formatBinary}
+	if v, err := r.decodeBinary(col, int64Bytes(-5)); err != nil || v != Decimal("-0.0005") {
+		t.Errorf("expected Decimal(-0.0005), got %v, %v", v, err)
+	}
+
+	col = &msgs.BERowDescColumnDef{FieldName: "i", DataTypeOID: common.ColTypeInt64, DataTypeName: "Integer", FormatCode: formatBinary}
+	if _, err := r.decodeBinary(col, []byte{1, 2}); err == nil {
+		t.Errorf("expected a short integer to be rejected")
+	}
+}
+
+func TestBinaryNumericText(t *testing.T) {
+	testCases := []struct {
+		value    []byte
+		scale    int
+		expected string
+	}{
+		{value: int64Bytes(0), scale: 2, expected: "0.00"},
+		{value: int64Bytes(7), scale: 0, expected: "7"},
+		{value: int64Bytes(-7), scale: 3, expected: "-0.007"},
+		{value: int64Bytes(math.MaxInt64), scale: 15, expected: "9223.372036854775807"},
+		{value: append([]byte{0x7f}, make([]byte, 15)...), scale: 0, expected: "168811955464684315858783496655603761152"},
+	}
+	for _, tc := range testCases {
+		if result := binaryNumericText(tc.value, tc.scale); result != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, result)
+		}
+	}
+}
+
+func TestBinaryResultFormats(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "i", DataTypeOID: common.ColTypeInt64},
+		{FieldName: "s", DataTypeOID: common.ColTypeVarChar},
+		{FieldName: "a", DataTypeOID: 1506},
+	}}
+	formats := binaryResultFormats(desc)
+	if !reflect.DeepEqual(formats, []uint16{formatBinary, formatText, formatText}) {
+		t.Fatalf("unexpected formats %v", formats)
+	}
+	if binaryResultFormats(&msgs.BERowDescMsg{Columns: desc.Columns[1:]}) != nil {
+		t.Errorf("expected no formats when no column can be binary")
+	}
+
+	marked := withResultFormats(desc, formats)
+	if marked.Columns[0].FormatCode != formatBinary || marked.Columns[1].FormatCode != formatText {
+		t.Errorf("unexpected format codes in %+v", marked.Columns)
+	}
+	if desc.Columns[0].FormatCode != formatText {
+		t.Errorf("expected the statement description to be left alone")
+	}
+	if withResultFormats(desc, nil) != desc {
+		t.Errorf("expected text results to keep the description")
+	}
+
+	// A binary column decodes through rows.Next like a text one.
+	r := newTestRows(t, marked)
+	msg := newTestDataRow(t, string(int64Bytes(42)), "text", "[1]")
+	if err := r.addRow(msg); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	result := make([]driver.Value, 3)
+	if err := r.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, []driver.Value{42, "text", Array{1}}) {
+		t.Errorf("unexpected row %v", result)
+	}
+}
+
+func TestStatementResultFormats(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{{FieldName: "i", DataTypeOID: common.ColTypeInt64}}}
+	s := &stmt{conn: &connection{}, lastRowDesc: desc}
+	if s.resultFormats(context.Background()) != nil {
+		t.Errorf("expected text results by default")
+	}
+
+	vCtx := NewVerticaContext(context.Background())
+	_ = vCtx.SetBinaryResults(true)
+	if formats := s.resultFormats(vCtx); !reflect.DeepEqual(formats, []uint16{formatBinary}) {
+		t.Errorf("expected binary results from the context, got %v", formats)
+	}
+
+	s.conn.config.BinaryResults = true
+	if formats := s.resultFormats(context.Background()); !reflect.DeepEqual(formats, []uint16{formatBinary}) {
+		t.Errorf("expected binary results from the connection, got %v", formats)
+	}
+	s.lastRowDesc = nil
+	if s.resultFormats(context.Background()) != nil {
+		t.Errorf("expected no formats for a statement without results")
+	}
+}
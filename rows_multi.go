@@ -81,6 +81,11 @@ func (m *multiRows) NextResultSet() error {
 	return nil
 }
 
+// Notices returns the notices the server sent while the current statement ran.
+func (m *multiRows) Notices() []*Notice {
+	return m.currentRows().Notices()
+}
+
 // The column metadata helpers simply forward to the active rows instance so
 // callers always see the schema for the current statement.
 func (m *multiRows) ColumnTypeDatabaseTypeName(index int) string {
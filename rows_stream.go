@@ -0,0 +1,259 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+var errStreamActive = errors.New("a streamed result set is still open on this connection; close it before issuing another statement")
+
+// streamCloseReadAhead is the number of rows rowStream.Close reads off an
+// unpaged result before it asks the server to cancel the rest.
+const streamCloseReadAhead = 64
+
+// rowStream is a rowStore that pulls DataRows off the wire as rows.Next asks
+// for them instead of buffering the whole result up front. It owns the
+// session lock from the moment it is handed to the caller until the server
+// has finished sending the result, at which point release is called.
+type rowStream struct {
+	stmt        *stmt
+	ctx         context.Context
+	simpleQuery bool // simple query results end with ReadyForQuery rather than CommandComplete
+	portal      string
+	fetchSize   uint32
+	closing     bool
+	canceled    bool // Close sent a cancel request for the rest of the result
+	pending     *msgs.BEDataRowMsg
+	done        bool
+	err         error
+	tag         string    // the command tag, once the server has sent it
+	notices     []*Notice // sent after the first row
+	release     func()
+}
+
+func newRowStream(ctx context.Context, s *stmt, firstRow *msgs.BEDataRowMsg, simpleQuery bool) *rowStream {
+	return &rowStream{
+		stmt:        s,
+		ctx:         ctx,
+		simpleQuery: simpleQuery,
+		pending:     firstRow,
+	}
+}
+
+// pageThrough makes the stream fetch the next fetchSize rows from the named
+// portal each time the server suspends it.
+func (r *rowStream) pageThrough(portal string, fetchSize uint32) {
+	r.portal = portal
+	r.fetchSize = fetchSize
+}
+
+// AddRow is never used for a stream as rows are read on demand.
+func (r *rowStream) AddRow(msg *msgs.BEDataRowMsg) error {
+	r.pending = msg
+	return nil
+}
+
+// GetRow returns the next row from the wire, or nil once the result is
+// exhausted or has failed. Check Err to tell the two apart.
+func (r *rowStream) GetRow() *msgs.BEDataRowMsg {
+	if r.pending != nil {
+		row := r.pending
+		r.pending = nil
+		return row
+	}
+	if r.done {
+		return nil
+	}
+	return r.fetch()
+}
+
+// Peek returns the row that will be handed out next, if it has already been read.
+func (r *rowStream) Peek() *msgs.BEDataRowMsg {
+	return r.pending
+}
+
+// Finalize is a noop for a stream; the end of the result is read from the wire.
+func (r *rowStream) Finalize() error {
+	return nil
+}
+
+// Close reads whatever the server still has to send for this result so that
+// the connection is left ready for the next statement. A paged result is only
+// read to the end of the current page before its portal is closed. Any other
+// result is canceled once more than a few rows remain, and the error the
+// server reports for the cancellation is not returned.
+func (r *rowStream) Close() error {
+	r.pending = nil
+	r.closing = true
+	for read := 0; !r.done; read++ {
+		if read == streamCloseReadAhead && r.portal == "" {
+			r.cancel()
+		}
+		r.fetch()
+	}
+	if r.canceled && errors.Is(r.err, ErrQueryCanceled) {
+		r.err = nil
+	}
+	return r.err
+}
+
+// cancel asks the server to stop sending the result. The rows it sent before
+// the request arrived are still read by Close.
+func (r *rowStream) cancel() {
+	conn := r.stmt.conn
+	if err := conn.cancelSession(context.Background(), conn.backendPID, conn.cancelKey); err != nil {
+		stmtLogger.Warn("unable to cancel the rest of a closed result: %v", err)
+		return
+	}
+	r.canceled = true
+}
+
+// Err reports the error that ended the stream early, if any.
+func (r *rowStream) Err() error {
+	return r.err
+}
+
+func (r *rowStream) fetch() *msgs.BEDataRowMsg {
+	conn := r.stmt.conn
+	for {
+		bMsg, err := conn.recvMessage()
+		if err != nil {
+			// The protocol state is unknown after a failed read.
+			conn.dead = true
+			r.finish(err)
+			return nil
+		}
+
+		switch msg := bMsg.(type) {
+		case *msgs.BEDataRowMsg:
+			return msg
+		case *msgs.BEErrorMsg:
+			if r.simpleQuery {
+				err = conn.drainUntilReady()
+			} else {
+				err = conn.sync()
+			}
+			if err == nil {
+				err = r.stmt.closePortal(r.portal)
+			}
+			if err == nil {
+				err = r.stmt.evaluateErrorMsg(msg)
+			}
+			r.finish(err)
+			return nil
+		case *msgs.BECmdCompleteMsg, *msgs.BEEmptyQueryResponseMsg:
+			if complete, ok := msg.(*msgs.BECmdCompleteMsg); ok {
+				r.tag = complete.Tag
+			}
+			if !r.simpleQuery {
+				r.finishPortal()
+				return nil
+			}
+		case *msgs.BEPortalSuspendedMsg:
+			if r.portal != "" && !r.closing {
+				if err = r.fetchNextPage(); err != nil {
+					conn.dead = true
+					r.finish(err)
+					return nil
+				}
+				continue
+			}
+			r.finishPortal()
+			return nil
+		case *msgs.BEReadyForQueryMsg:
+			r.finish(r.ctx.Err())
+			return nil
+		case *msgs.BERowDescMsg, *msgs.BEBindCompleteMsg, *msgs.BECmdDescriptionMsg:
+			continue
+		default:
+			_, _ = conn.defaultMessageHandler(msg)
+		}
+	}
+}
+
+func (r *rowStream) fetchNextPage() error {
+	conn := r.stmt.conn
+	if err := conn.sendMessage(&msgs.FEExecuteMsg{Portal: r.portal, RowLimit: r.fetchSize}); err != nil {
+		return err
+	}
+	return conn.sendMessage(&msgs.FEFlushMsg{})
+}
+
+// finishPortal ends a result that completed or was suspended, releasing its
+// portal if it was a named one.
+func (r *rowStream) finishPortal() {
+	if err := r.stmt.closePortal(r.portal); err != nil {
+		r.finish(err)
+		return
+	}
+	r.finish(r.ctx.Err())
+}
+
+func (r *rowStream) finish(err error) {
+	r.done = true
+	r.notices = r.stmt.conn.takeNotices()
+	if r.err == nil {
+		r.err = err
+	}
+	if r.stmt.conn.activeStream == r {
+		r.stmt.conn.activeStream = nil
+	}
+	if r.release != nil {
+		r.release()
+		r.release = nil
+	}
+}
+
+// attach hands the session lock over to the stream. It returns false if the
+// result was fully read before it could be handed to the caller.
+func (r *rowStream) attach(release func()) bool {
+	if r.done {
+		return false
+	}
+	r.release = release
+	r.stmt.conn.activeStream = r
+	return true
+}
+
+// streamResults reports whether the caller asked for results to be streamed
+// from the socket instead of buffered.
+func streamResults(ctx context.Context) bool {
+	if vCtx, ok := ctx.(VerticaContext); ok {
+		return vCtx.GetStreamResults()
+	}
+	return false
+}
@@ -0,0 +1,339 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+)
+
+// writeBackEndMsg frames a backend message the way the server puts it on the wire.
+func writeBackEndMsg(t *testing.T, w io.Writer, msgType byte, body []byte) {
+	t.Helper()
+	header := make([]byte, 5)
+	header[0] = msgType
+	binary.BigEndian.PutUint32(header[1:], uint32(len(body)+4))
+	if _, err := w.Write(append(header, body...)); err != nil {
+		t.Errorf("unable to write message '%c': %v", msgType, err)
+	}
+}
+
+// newStreamTestRows returns rows streaming from a piped connection, plus the
+// server end of the pipe. The first row has already been read.
+func newStreamTestRows(t *testing.T, simpleQuery bool, released *int) (*rows, net.Conn) {
+	t.Helper()
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	s := &stmt{conn: &connection{conn: client}}
+	r := newRows(context.Background(), buildRowDesc("name"), nil)
+	r.startStream(context.Background(), s, newTestDataRow(t, "first"), simpleQuery)
+	if !r.attachStream(func() { *released++ }) {
+		t.Fatalf("expected stream to take the session")
+	}
+	return r, server
+}
+
+func TestRowStreamReadsRowsOnDemand(t *testing.T) {
+	released := 0
+	r, server := newStreamTestRows(t, false, &released)
+	conn := r.stream.stmt.conn
+
+	if conn.activeStream != r.stream {
+		t.Fatalf("expected the connection to record the active stream")
+	}
+
+	go func() {
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "second"))
+		writeBackEndMsg(t, server, 'C', []byte("SELECT 2\x00"))
+	}()
+
+	dest := make([]driver.Value, 1)
+	for _, expected := range []string{"first", "second"} {
+		if err := r.Next(dest); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dest[0] != expected {
+			t.Fatalf("expected %q, got %v", expected, dest[0])
+		}
+	}
+	if released != 0 {
+		t.Fatalf("session released before the end of the result")
+	}
+	if err := r.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+	if conn.activeStream != nil {
+		t.Fatalf("expected the active stream to be cleared")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+}
+
+func TestRowStreamCloseDrainsRemainingRows(t *testing.T) {
+	released := 0
+	r, server := newStreamTestRows(t, true, &released)
+
+	go func() {
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "second"))
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "third"))
+		writeBackEndMsg(t, server, 'C', []byte("SELECT 3\x00"))
+		writeBackEndMsg(t, server, 'Z', []byte("I"))
+	}()
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+	if err := r.Next(make([]driver.Value, 1)); err != io.EOF {
+		t.Fatalf("expected io.EOF after close, got %v", err)
+	}
+}
+
+func TestRowStreamCloseCancelsLongResult(t *testing.T) {
+	released := 0
+	r, server := newStreamTestRows(t, true, &released)
+	conn := r.stream.stmt.conn
+	conn.backendPID, conn.cancelKey = 7, 11
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer listener.Close()
+	conn.connHostsList = []string{listener.Addr().String()}
+
+	cancelMsg := make(chan []byte, 1)
+	go func() {
+		cancelConn, err := listener.Accept()
+		if err != nil {
+			t.Errorf("unable to accept the cancel request: %v", err)
+			cancelMsg <- nil
+			return
+		}
+		defer cancelConn.Close()
+		msg := make([]byte, 16)
+		if _, err := io.ReadFull(cancelConn, msg); err != nil {
+			t.Errorf("unable to read the cancel request: %v", err)
+		}
+		cancelMsg <- msg
+	}()
+
+	go func() {
+		for i := 0; i < streamCloseReadAhead; i++ {
+			writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "more"))
+		}
+		msg := <-cancelMsg
+		if binary.BigEndian.Uint32(msg[8:]) != 7 || binary.BigEndian.Uint32(msg[12:]) != 11 {
+			t.Errorf("expected the session's PID and key, got %v", msg)
+		}
+		writeBackEndMsg(t, server, 'E', []byte("SERROR\x00C57014\x00Mexecution canceled\x00\x00"))
+		writeBackEndMsg(t, server, 'Z', []byte("I"))
+	}()
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+}
+
+func TestRowStreamReportsErrorMidStream(t *testing.T) {
+	released := 0
+	r, server := newStreamTestRows(t, true, &released)
+
+	go func() {
+		writeBackEndMsg(t, server, 'E', []byte("SERROR\x00Mdivision by zero\x00\x00"))
+		writeBackEndMsg(t, server, 'Z', []byte("I"))
+	}()
+
+	dest := make([]driver.Value, 1)
+	if err := r.Next(dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := r.Next(dest)
+	vErr, ok := err.(*VError)
+	if !ok || vErr.Severity != "ERROR" {
+		t.Fatalf("expected a VError, got %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+	if closeErr := r.Close(); closeErr != err {
+		t.Fatalf("expected Close to report the stream error, got %v", closeErr)
+	}
+}
+
+// readFrontEndMsg reads one message sent by the driver and returns its type and body.
+func readFrontEndMsg(t *testing.T, r io.Reader) (byte, []byte) {
+	t.Helper()
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		t.Errorf("unable to read message header: %v", err)
+		return 0, nil
+	}
+	body := make([]byte, binary.BigEndian.Uint32(header[1:])-4)
+	if _, err := io.ReadFull(r, body); err != nil {
+		t.Errorf("unable to read message body: %v", err)
+	}
+	return header[0], body
+}
+
+func TestRowStreamPagesThroughPortal(t *testing.T) {
+	released := 0
+	r, server := newStreamTestRows(t, false, &released)
+	r.stream.pageThrough("P1", 2)
+
+	go func() {
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "second"))
+		writeBackEndMsg(t, server, 's', nil)
+
+		// The driver asks for the next page of the same portal.
+		if msgType, body := readFrontEndMsg(t, server); msgType != 'E' || string(body) != "P1\x00\x00\x00\x00\x02" {
+			t.Errorf("expected Execute of portal P1 for 2 rows, got '%c' %q", msgType, body)
+		}
+		if msgType, _ := readFrontEndMsg(t, server); msgType != 'H' {
+			t.Errorf("expected Flush, got '%c'", msgType)
+		}
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "third"))
+		writeBackEndMsg(t, server, 'C', []byte("SELECT 3\x00"))
+
+		// Once complete the portal is closed.
+		if msgType, body := readFrontEndMsg(t, server); msgType != 'C' || string(body) != "PP1\x00" {
+			t.Errorf("expected Close of portal P1, got '%c' %q", msgType, body)
+		}
+		if msgType, _ := readFrontEndMsg(t, server); msgType != 'H' {
+			t.Errorf("expected Flush, got '%c'", msgType)
+		}
+		writeBackEndMsg(t, server, '3', nil)
+	}()
+
+	dest := make([]driver.Value, 1)
+	for _, expected := range []string{"first", "second", "third"} {
+		if err := r.Next(dest); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dest[0] != expected {
+			t.Fatalf("expected %q, got %v", expected, dest[0])
+		}
+	}
+	if err := r.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+}
+
+func TestRowStreamCloseStopsPaging(t *testing.T) {
+	released := 0
+	r, server := newStreamTestRows(t, false, &released)
+	r.stream.pageThrough("P1", 2)
+
+	go func() {
+		writeBackEndMsg(t, server, 'D', *newTestDataRow(t, "second"))
+		writeBackEndMsg(t, server, 's', nil)
+
+		// No further Execute: the portal is closed straight away.
+		if msgType, _ := readFrontEndMsg(t, server); msgType != 'C' {
+			t.Errorf("expected Close, got '%c'", msgType)
+		}
+		readFrontEndMsg(t, server)
+		writeBackEndMsg(t, server, '3', nil)
+	}()
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("expected session to be released once, got %d", released)
+	}
+}
+
+func TestRowsNextRejectsRowWiderThanColumns(t *testing.T) {
+	r := newTestRows(t, buildRowDesc("one"), []string{"a", "b"})
+	if err := r.Next(make([]driver.Value, 1)); err == nil {
+		t.Fatalf("expected an error for a row wider than the described columns")
+	}
+}
+
+func TestStreamResultsContext(t *testing.T) {
+	if streamResults(context.Background()) {
+		t.Fatalf("plain contexts must not stream")
+	}
+	vCtx := NewVerticaContext(context.Background())
+	if streamResults(vCtx) {
+		t.Fatalf("streaming must be opt-in")
+	}
+	_ = vCtx.SetStreamResults(true)
+	if !streamResults(vCtx) {
+		t.Fatalf("expected streaming to be enabled")
+	}
+}
+
+func TestStatementFetchSize(t *testing.T) {
+	s := &stmt{conn: &connection{config: Config{FetchSize: 100}}}
+	if n := s.fetchSize(context.Background()); n != 100 {
+		t.Fatalf("expected the connection fetch size, got %d", n)
+	}
+	vCtx := NewVerticaContext(context.Background())
+	if n := s.fetchSize(vCtx); n != 100 {
+		t.Fatalf("expected an unset context to fall back to the connection, got %d", n)
+	}
+	if err := vCtx.SetFetchSize(-1); err == nil {
+		t.Fatalf("expected a negative fetch size to be rejected")
+	}
+	_ = vCtx.SetFetchSize(10)
+	if n := s.fetchSize(vCtx); n != 10 {
+		t.Fatalf("expected the context fetch size, got %d", n)
+	}
+}
+
+var _ rowStore = (*rowStream)(nil)
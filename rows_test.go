@@ -35,10 +35,15 @@ package vertigo
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/binary"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/vertica/vertica-sql-go/common"
 	"github.com/vertica/vertica-sql-go/msgs"
 )
 
@@ -65,7 +70,7 @@ func mockRow() []byte {
 	return buf.Bytes()
 }
 
-//Simulate loading a bunch of rows from messages and then extracting them with Next()
+// Simulate loading a bunch of rows from messages and then extracting them with Next()
 func BenchmarkRows(b *testing.B) {
 	const rowCount = 10000
 	var msgType msgs.BEDataRowMsg
@@ -75,7 +80,7 @@ func BenchmarkRows(b *testing.B) {
 	}
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
-		rows := newRows(context.Background(), makeColumnDef(), "")
+		rows := newRows(context.Background(), makeColumnDef(), nil)
 		for i := 0; i < rowCount; i++ {
 			rowI, _ := msgType.CreateFromMsgBody(msgs.NewMsgBufferFromBytes(mockData[i]))
 			rows.addRow(rowI.(*msgs.BEDataRowMsg))
@@ -98,7 +103,7 @@ func BenchmarkRowsWithLimit(b *testing.B) {
 	}
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
-		rows := newRows(vCtx, makeColumnDef(), "")
+		rows := newRows(vCtx, makeColumnDef(), nil)
 		for i := 0; i < rowCount; i++ {
 			rowI, _ := msgType.CreateFromMsgBody(msgs.NewMsgBufferFromBytes(mockData[i]))
 			rows.addRow(rowI.(*msgs.BEDataRowMsg))
@@ -119,7 +124,7 @@ func TestTimestamp(t *testing.T) {
 	colDef := &msgs.BERowDescMsg{Columns: cols}
 
 	var msgType msgs.BEDataRowMsg
-	rows := newRows(vCtx, colDef, "")
+	rows := newRows(vCtx, colDef, nil)
 	row1 := bytes.NewBuffer(make([]byte, 0, 30))
 	binary.Write(row1, binary.BigEndian, int16(1))
 	binary.Write(row1, binary.BigEndian, int32(29))
@@ -151,3 +156,181 @@ func TestTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestExactNumericColumn(t *testing.T) {
+	const value = "12345678901234567890123456.0123456789"
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "n", DataTypeOID: common.ColTypeNumeric, DataTypeName: "numeric", DataTypeMod: -1},
+	}}
+	result := make([]driver.Value, 1)
+
+	rows := newTestRows(t, desc, []string{value})
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := result[0].(float64); !ok {
+		t.Errorf("expected NUMERIC to default to float64, got %T", result[0])
+	}
+	if scanType := rows.ColumnTypeScanType(0); scanType != reflect.TypeOf(sql.NullFloat64{}) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+
+	rows = newTestRows(t, desc, []string{value})
+	rows.exactNumeric = true
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result[0] != Decimal(value) {
+		t.Errorf("expected Decimal(%s), got %T(%v)", value, result[0], result[0])
+	}
+	if scanType := rows.ColumnTypeScanType(0); scanType != reflect.TypeOf(NullDecimal{}) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+}
+
+func TestNextRowWiderThanDest(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "a", DataTypeOID: common.ColTypeVarChar, DataTypeName: "varchar"},
+		{FieldName: "b", DataTypeOID: common.ColTypeVarChar, DataTypeName: "varchar"},
+	}}
+
+	rows := newTestRows(t, desc, []string{"x", "y"})
+	err := rows.Next(make([]driver.Value, 1))
+	if err == nil || err.Error() != "row has 2 columns but only 1 destination values were given" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	rows = newTestRows(t, desc, []string{"x", "y", "z"})
+	err = rows.Next(make([]driver.Value, 3))
+	if err == nil || err.Error() != "row has 3 columns but the result describes 2" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "ts", DataTypeOID: common.ColTypeTimestampTZ, DataTypeName: "timestamptz"},
+		{FieldName: "n", DataTypeOID: common.ColTypeInt64, DataTypeName: "integer"},
+		{FieldName: "s", DataTypeOID: common.ColTypeVarChar, DataTypeName: "varchar"},
+	}}
+	badRow := []string{"2024-13-45 25:00:00+00", "12x", "ok"}
+	result := make([]driver.Value, 3)
+
+	rows := newTestRows(t, desc, badRow)
+	err := rows.Next(result)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected a *DecodeError, got %v", err)
+	}
+	if decodeErr.Column != 0 || decodeErr.ColumnName != "ts" || decodeErr.TypeOID != common.ColTypeTimestampTZ ||
+		string(decodeErr.Raw) != "2024-13-45 25:00:00+00" || decodeErr.Err == nil {
+		t.Errorf("unexpected decode error %+v", decodeErr)
+	}
+
+	vCtx := NewVerticaContext(context.Background())
+	if err := vCtx.SetLenientDecoding(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := &stmt{conn: &connection{}}
+	lenient := s.newRows(vCtx, desc)
+	if !lenient.lenient || lenient.decodeErrs == nil {
+		t.Fatalf("expected the context to make the rows lenient")
+	}
+
+	rows = newTestRows(t, desc, badRow, []string{"2024-02-29 13:14:15+00", "7", "fine"})
+	rows.lenient = true
+	rows.decodeErrs = lenient.decodeErrs
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, []driver.Value{nil, nil, "ok"}) {
+		t.Errorf("expected failed values to become NULL, got %v", result)
+	}
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result[1] != 7 {
+		t.Errorf("unexpected second row %v", result)
+	}
+
+	errs := vCtx.DecodeErrors()
+	if len(errs) != 2 || errs[0].Column != 0 || errs[1].Column != 1 || string(errs[1].Raw) != "12x" {
+		t.Errorf("unexpected decode errors %v", errs)
+	}
+}
+
+func TestTimestampTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "winter", DataTypeOID: common.ColTypeTimestamp, DataTypeName: "timestamp"},
+		{FieldName: "summer", DataTypeOID: common.ColTypeTimestamp, DataTypeName: "timestamp"},
+		{FieldName: "t", DataTypeOID: common.ColTypeTime, DataTypeName: "time"},
+	}}
+	data := []string{"2024-01-15 12:00:00", "2024-07-15 12:00:00.5", "08:30:00"}
+	result := make([]driver.Value, 3)
+
+	rows := newRows(context.Background(), desc, newYork)
+	for _, row := range []*msgs.BEDataRowMsg{newTestDataRow(t, data...), newTestDataRow(t, data...)} {
+		if err := rows.addRow(row); err != nil {
+			t.Fatalf("failed to add row: %v", err)
+		}
+	}
+	if err := rows.finalize(); err != nil {
+		t.Fatalf("failed to finalize: %v", err)
+	}
+
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC); !result[0].(time.Time).Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, result[0])
+	}
+	if expected := time.Date(2024, 7, 15, 16, 0, 0, 500000000, time.UTC); !result[1].(time.Time).Equal(expected) {
+		t.Errorf("expected %v across DST, got %v", expected, result[1])
+	}
+	if tm := result[2].(time.Time); tm.Hour() != 8 || tm.Minute() != 30 {
+		t.Errorf("expected the wall clock of the time to be kept, got %v", tm)
+	}
+
+	rows.naiveTimestamps = true
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2024, 7, 15, 12, 0, 0, 500000000, time.UTC); result[1] != expected {
+		t.Errorf("expected the naive timestamp %v, got %v", expected, result[1])
+	}
+}
+
+func TestServerTimeZoneTracking(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Paris"); err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	offset := time.FixedZone("-05:00", -5*3600)
+	conn := &connection{serverLocation: offset}
+
+	conn.paramStatus(&msgs.BEParamStatusMsg{ParamName: "TimeZone", ParamValue: "Europe/Paris"})
+	if conn.serverLocation.String() != "Europe/Paris" {
+		t.Errorf("expected the zone to follow ParameterStatus, got %v", conn.serverLocation)
+	}
+	conn.setServerTimeZone("not a zone")
+	if conn.serverLocation.String() != "Europe/Paris" {
+		t.Errorf("expected an unknown zone to be ignored, got %v", conn.serverLocation)
+	}
+
+	for command, expected := range map[string]bool{
+		"SET TIME ZONE TO 'UTC'":                     true,
+		"set timezone 'America/New_York'":            true,
+		"SELECT 1; SET SESSION TIME ZONE TO DEFAULT": true,
+		"SELECT 'SET TIME ZONE'":                     false,
+		"SELECT 1 -- SET TIME ZONE TO 'UTC'":         false,
+		"/* SET TIME ZONE */ SELECT 1":               false,
+		"SET SESSION AUTOCOMMIT TO on":               false,
+	} {
+		if setsTimeZone(command) != expected {
+			t.Errorf("%q: expected %v", command, expected)
+		}
+	}
+}
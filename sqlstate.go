@@ -0,0 +1,153 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// SQLSTATE codes of the errors Vertica reports, see VError.SQLState.
+const (
+	SQLStateConnectionException   = "08000" // and the rest of class 08
+	SQLStateUniqueViolation       = "23505"
+	SQLStateSerializationFailure  = "40001"
+	SQLStateDeadlock              = "40V01"
+	SQLStateInsufficientPrivilege = "42501"
+	SQLStateSyntaxError           = "42601"
+	SQLStateUndefinedColumn       = "42703"
+	SQLStateUndefinedTable        = "42V01"
+	SQLStateLockTimeout           = "55V03"
+	SQLStateQueryCanceled         = "57014"
+	SQLStateAdminShutdown         = "57P01"
+	SQLStateCrashShutdown         = "57P02"
+	SQLStateCannotConnectNow      = "57P03"
+)
+
+// Vertica error codes, see VError.ErrorCode.
+const (
+	ErrorCodePermissionDenied = "4367"
+	ErrorCodeRelationNotFound = "4566"
+	ErrorCodeSyntaxError      = "4856"
+	ErrorCodeDuplicateKey     = "6745"
+)
+
+// Classes of errors a *VError matches with errors.Is, for example
+// errors.Is(err, vertigo.ErrLockTimeout).
+var (
+	ErrConnectionFailure     = errors.New("connection failure")
+	ErrUniqueViolation       = errors.New("unique constraint violation")
+	ErrSerializationFailure  = errors.New("serialization failure")
+	ErrDeadlock              = errors.New("deadlock detected")
+	ErrInsufficientPrivilege = errors.New("insufficient privilege")
+	ErrSyntax                = errors.New("syntax error")
+	ErrUndefinedTable        = errors.New("undefined table")
+	ErrLockTimeout           = errors.New("lock timeout")
+	ErrQueryCanceled         = errors.New("query canceled")
+)
+
+// Is reports whether the error belongs to the class target, one of the
+// ErrConnectionFailure, ErrLockTimeout, ... errors.
+func (ve *VError) Is(target error) bool {
+	switch target {
+	case ErrConnectionFailure:
+		return strings.HasPrefix(ve.SQLState, SQLStateConnectionException[:2]) ||
+			ve.SQLState == SQLStateAdminShutdown || ve.SQLState == SQLStateCrashShutdown ||
+			ve.SQLState == SQLStateCannotConnectNow
+	case ErrUniqueViolation:
+		return ve.SQLState == SQLStateUniqueViolation || ve.ErrorCode == ErrorCodeDuplicateKey
+	case ErrSerializationFailure:
+		return ve.SQLState == SQLStateSerializationFailure
+	case ErrDeadlock:
+		return ve.SQLState == SQLStateDeadlock
+	case ErrInsufficientPrivilege:
+		return ve.SQLState == SQLStateInsufficientPrivilege || ve.ErrorCode == ErrorCodePermissionDenied
+	case ErrSyntax:
+		return ve.SQLState == SQLStateSyntaxError || ve.ErrorCode == ErrorCodeSyntaxError
+	case ErrUndefinedTable:
+		return ve.SQLState == SQLStateUndefinedTable || ve.ErrorCode == ErrorCodeRelationNotFound
+	case ErrLockTimeout:
+		return ve.SQLState == SQLStateLockTimeout
+	case ErrQueryCanceled:
+		return ve.SQLState == SQLStateQueryCanceled
+	}
+	return false
+}
+
+// IsLockTimeout reports whether err is a failure to acquire a lock in time.
+func IsLockTimeout(err error) bool {
+	return errors.Is(err, ErrLockTimeout)
+}
+
+// IsUniqueViolation reports whether err is a duplicate value in a column
+// with an enforced primary key or unique constraint.
+func IsUniqueViolation(err error) bool {
+	return errors.Is(err, ErrUniqueViolation)
+}
+
+// IsInsufficientPrivilege reports whether err is the user lacking a privilege
+// the statement needs.
+func IsInsufficientPrivilege(err error) bool {
+	return errors.Is(err, ErrInsufficientPrivilege)
+}
+
+// IsConnectionFailure reports whether err means the connection to the server
+// was lost or refused, either as reported by the server or by the network.
+func IsConnectionFailure(err error) bool {
+	// context.DeadlineExceeded is a net.Error too, but a statement that ran
+	// out of time has not lost its connection.
+	if err == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrConnectionFailure) || errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsRetryable reports whether err is transient, so that running the statement
+// again may succeed: a lock timeout, a deadlock, a serialization failure or a
+// lost connection. Whether it is safe to run the statement again is up to the
+// caller.
+func IsRetryable(err error) bool {
+	return IsLockTimeout(err) || errors.Is(err, ErrDeadlock) ||
+		errors.Is(err, ErrSerializationFailure) || IsConnectionFailure(err)
+}
@@ -0,0 +1,132 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestVErrorClasses(t *testing.T) {
+	lockTimeout := &VError{Severity: "ERROR", SQLState: SQLStateLockTimeout, ErrorCode: "5156"}
+	duplicate := &VError{Severity: "ERROR", SQLState: SQLStateUniqueViolation, ErrorCode: ErrorCodeDuplicateKey}
+	denied := &VError{Severity: "ERROR", SQLState: SQLStateInsufficientPrivilege, ErrorCode: ErrorCodePermissionDenied}
+	syntax := &VError{Severity: "ERROR", SQLState: SQLStateSyntaxError, ErrorCode: ErrorCodeSyntaxError}
+	missing := &VError{Severity: "ERROR", SQLState: SQLStateUndefinedTable, ErrorCode: ErrorCodeRelationNotFound}
+	deadlock := &VError{Severity: "ROLLBACK", SQLState: SQLStateDeadlock}
+	shutdown := &VError{Severity: "FATAL", SQLState: SQLStateAdminShutdown}
+	commFailure := &VError{Severity: "FATAL", SQLState: "08006"}
+
+	classes := []error{ErrLockTimeout, ErrUniqueViolation, ErrInsufficientPrivilege, ErrSyntax, ErrUndefinedTable,
+		ErrDeadlock, ErrConnectionFailure, ErrSerializationFailure, ErrQueryCanceled}
+	tests := []struct {
+		err   *VError
+		class error
+	}{
+		{lockTimeout, ErrLockTimeout},
+		{duplicate, ErrUniqueViolation},
+		{denied, ErrInsufficientPrivilege},
+		{syntax, ErrSyntax},
+		{missing, ErrUndefinedTable},
+		{deadlock, ErrDeadlock},
+		{shutdown, ErrConnectionFailure},
+		{commFailure, ErrConnectionFailure},
+	}
+	for _, tc := range tests {
+		for _, class := range classes {
+			if got := errors.Is(tc.err, class); got != (class == tc.class) {
+				t.Errorf("errors.Is(%v, %v) = %v", tc.err, class, got)
+			}
+		}
+	}
+
+	// The class is found through wrapping.
+	wrapped := fmt.Errorf("loading: %w", duplicate)
+	if !IsUniqueViolation(wrapped) || IsLockTimeout(wrapped) {
+		t.Errorf("wrong class for %v", wrapped)
+	}
+	if !IsInsufficientPrivilege(denied) {
+		t.Errorf("expected %v to be a privilege error", denied)
+	}
+	if !IsLockTimeout(lockTimeout) {
+		t.Errorf("expected %v to be a lock timeout", lockTimeout)
+	}
+	// Either field is enough where both are known.
+	if !IsUniqueViolation(&VError{ErrorCode: ErrorCodeDuplicateKey}) {
+		t.Error("expected the error code alone to be a unique violation")
+	}
+}
+
+func TestIsConnectionFailure(t *testing.T) {
+	failures := []error{
+		&VError{SQLState: "08001"},
+		driver.ErrBadConn,
+		io.EOF,
+		fmt.Errorf("reading: %w", io.ErrUnexpectedEOF),
+		&net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")},
+	}
+	for _, err := range failures {
+		if !IsConnectionFailure(err) {
+			t.Errorf("expected %v to be a connection failure", err)
+		}
+		if !IsRetryable(err) {
+			t.Errorf("expected %v to be retryable", err)
+		}
+	}
+
+	others := []error{nil, errors.New("other"), &VError{SQLState: SQLStateSyntaxError},
+		context.DeadlineExceeded, context.Canceled, fmt.Errorf("querying: %w", context.DeadlineExceeded)}
+	for _, err := range others {
+		if IsConnectionFailure(err) {
+			t.Errorf("did not expect %v to be a connection failure", err)
+		}
+		if IsRetryable(err) {
+			t.Errorf("did not expect %v to be retryable", err)
+		}
+	}
+
+	for _, err := range []error{
+		&VError{SQLState: SQLStateLockTimeout},
+		&VError{SQLState: SQLStateDeadlock},
+		&VError{SQLState: SQLStateSerializationFailure},
+	} {
+		if !IsRetryable(err) {
+			t.Errorf("expected %v to be retryable", err)
+		}
+	}
+}
@@ -42,8 +42,8 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
-	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -69,24 +69,31 @@ const (
 
 type stmt struct {
 	conn         *connection
-	command      string
+	query        string           // the SQL as the caller submitted it
+	command      string           // the SQL with named parameters replaced by ?
+	lexOffsets   parse.OffsetMap  // maps command to query
+	argOffsets   parse.OffsetMap  // maps the last interpolated command to command
+	sentSQL      string           // the SQL last sent to the server, if it can be found in query
+	sentOffsets  *parse.OffsetMap // maps sentSQL to the interpolated command; nil if sentSQL is command
 	preparedName string
 	parseState   parseState
 	namedArgPos  []string
 	posArgCnt    int
 	paramTypes   []common.ParameterType
 	lastRowDesc  *msgs.BERowDescMsg
+	describedTag string // the command tag reported when the statement was described
+	generation   int    // the connection generation the statement was prepared in
 	// set if Vertica issues an error of ROLLBACK severity
 	rolledBack      bool
 	multiStatements bool
 }
 
-
 func newStmt(connection *connection, command string) (*stmt, error) {
 	s := &stmt{
 		conn:         connection,
+		query:        command,
 		command:      command,
-		preparedName: fmt.Sprintf("S%d%d%d", os.Getpid(), time.Now().Unix(), rand.Int31()),
+		preparedName: newPreparedName(),
 		parseState:   parseStateUnparsed,
 	}
 
@@ -105,7 +112,8 @@ func newStmt(connection *connection, command string) (*stmt, error) {
 		s.posArgCnt++
 		return "?"
 	}
-	s.command = parse.Lex(command, parse.WithNamedCallback(s.pushNamed), parse.WithPositionalSubstitution(argCounter))
+	s.command = parse.Lex(command, parse.WithNamedCallback(s.pushNamed), parse.WithPositionalSubstitution(argCounter),
+		parse.WithOffsetMap(&s.lexOffsets))
 	finalStatements := parse.SplitStatements(s.command)
 	if len(finalStatements) == 0 {
 		s.command = ""
@@ -116,6 +124,12 @@ func newStmt(connection *connection, command string) (*stmt, error) {
 	return s, nil
 }
 
+// newPreparedName returns a name for a server-side prepared statement that no
+// other statement of the session uses.
+func newPreparedName() string {
+	return fmt.Sprintf("S%d%d%d", os.Getpid(), time.Now().Unix(), rand.Int31())
+}
+
 func (s *stmt) pushNamed(name string) {
 	s.namedArgPos = append(s.namedArgPos, name)
 }
@@ -125,6 +139,12 @@ func (s *stmt) Close() error {
 	if s.parseState != parseStateParsed {
 		return nil
 	}
+	if s.generation != s.conn.generation {
+		// The session the statement was prepared in is gone, and with it the
+		// statement.
+		s.parseState = parseStateUnparsed
+		return nil
+	}
 	if s.rolledBack {
 		s.parseState = parseStateUnparsed
 		s.conn.dead = true
@@ -229,25 +249,39 @@ func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
 func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
 	stmtLogger.Trace("stmt.ExecContext()")
 
-	rows, err := s.QueryContext(ctx, args)
+	rs, err := s.run(ctx, args)
 
-	if err != nil {
+	// A script run with SetContinueOnError still reports the statements that
+	// succeeded.
+	if _, ok := err.(*ScriptError); err != nil && !ok {
 		return driver.ResultNoRows, err
 	}
+	defer rs.Close()
 
-	numCols := len(rows.Columns())
-	vals := make([]driver.Value, numCols)
-
-	if rows.Next(vals) == io.EOF {
-		return driver.ResultNoRows, nil
+	res, resErr := execResult(rs)
+	if resErr != nil {
+		return driver.ResultNoRows, resErr
 	}
+	return res, err
+}
 
-	rv := reflect.ValueOf(vals[0])
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rs, err := s.run(ctx, args)
 
-	return &result{lastInsertID: 0, rowsAffected: rv.Int()}, nil
+	// database/sql never closes the rows it is given with an error, so the
+	// rows of a script that failed part way are closed here.
+	if _, ok := err.(*ScriptError); ok {
+		rs.Close()
+		return newEmptyRows(), err
+	}
+	return rs, err
 }
 
-func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+// run runs the statement, retrying it if the caller's policy allows.
+func (s *stmt) run(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if policy := s.retryPolicy(ctx); policy != nil && s.safeToRetry(ctx) {
+		return s.queryWithRetry(ctx, args, policy)
+	}
 	return s.QueryContextRaw(ctx, args)
 }
 
@@ -264,45 +298,67 @@ func (s *stmt) QueryContextRaw(ctx context.Context, baseArgs []driver.NamedValue
 		return newEmptyRows(), nil
 	}
 
-	doneChan := make(chan bool, 1)
-	go func(pid, key uint32) {
-		select {
-		case <-doneChan:
-			return
-		case <-ctx.Done():
-			stmtLogger.Info("Context cancelled, cancelling %s", s.preparedName)
-			cancelMsg := msgs.FECancelMsg{PID: pid, Key: key}
-			conn, err := s.conn.establishSocketConnection()
-			if err != nil {
-				stmtLogger.Warn("unable to establish connection for cancellation")
-				return
-			}
-			conn.SetDeadline(time.Now().Add(10 * time.Second))
-			if err := s.conn.sendMessageTo(&cancelMsg, conn); err != nil {
-				stmtLogger.Warn("unable to send cancel message: %v", err)
-			}
-			if err := conn.Close(); err != nil {
-				stmtLogger.Warn("error closing cancel connection: %v", err)
-			}
-			stmtLogger.Info("Cancelled %s", s.preparedName)
+	// A statement prepared before the connection was re-established has to be
+	// prepared again in the new session.
+	if s.parseState == parseStateParsed && s.generation != s.conn.generation {
+		s.parseState = parseStateUnparsed
+		if err = s.prepareAndDescribe(); err != nil {
+			return newEmptyRows(), err
 		}
-	}(s.conn.backendPID, s.conn.cancelKey)
+	}
+
+	doneChan := s.watchForCancel(ctx)
+
+	if s.conn.activeStream != nil {
+		doneChan <- true
+		return newEmptyRows(), errStreamActive
+	}
 
 	s.conn.lockSessionMutex()
-	defer s.conn.unlockSessionMutex()
-	defer func() {
+	s.conn.noticeHandler = noticeHandler(ctx)
+	release := func() {
 		doneChan <- true
+		s.conn.noticeHandler = nil
+		s.conn.unlockSessionMutex()
+	}
+	// A streamed result takes over the session lock and the cancel watcher
+	// until the server has sent the last row.
+	streaming := false
+	defer func() {
+		if !streaming {
+			release()
+		}
 	}()
 
+	s.conn.refreshTimeZone()
+	if setsTimeZone(s.command) {
+		// Deferred after the release so that it runs first, with the session held.
+		defer func() { s.conn.timeZoneStale = true }()
+	}
+
 	// LOCAL COPY must always use the simple query protocol. With the prepared-
 	// statement path, bindAndExecute sends FEFlushMsg right after FEExecuteMsg;
 	// the server enters GetLocalFileInfo state while processing FEExecuteMsg and
 	// then rejects the FEFlushMsg with "Flush is invalid in state GetLocalFileInfo".
 	if s.parseState == parseStateParsed && !s.isLocalCopyStatement() {
-		if err = s.bindAndExecute("", args); err != nil {
+		// With a fetch size the rows are pulled from a named portal in pages,
+		// so the portal has to outlive the first Execute.
+		portalName := ""
+		fetchSize := s.fetchSize(ctx)
+		if fetchSize > 0 {
+			portalName = "P" + s.preparedName
+		}
+		resultFormats := s.resultFormats(ctx)
+		s.setSent(s.command, nil)
+		if err = s.bindAndExecute(portalName, args, fetchSize, resultFormats); err != nil {
 			return newEmptyRows(), err
 		}
-		return s.collectResults(ctx)
+		result, err := s.collectResults(ctx, portalName, fetchSize, resultFormats)
+		result.statement = s.command
+		if err == nil {
+			streaming = result.attachStream(release)
+		}
+		return result, err
 	}
 
 	interpolated, err := s.interpolate(args)
@@ -310,19 +366,27 @@ func (s *stmt) QueryContextRaw(ctx context.Context, baseArgs []driver.NamedValue
 		return newEmptyRows(), err
 	}
 
-	statements := parse.SplitStatements(interpolated)
+	statements, statementOffsets := parse.SplitStatementsMapped(interpolated)
 	if len(statements) == 0 {
 		return newEmptyRows(), nil
 	}
 
+	// Only a lone statement can be streamed; a multi-statement result is
+	// stitched together from fully read parts.
+	stream := len(statements) == 1 && streamResults(ctx)
+	keepGoing := len(statements) > 1 && continueOnError(ctx)
+
 	resultSets := make([]*rows, 0, len(statements))
-	for _, statementSQL := range statements {
+	var failed []*StatementError
+	for idx, statementSQL := range statements {
 		execSQL := statementSQL
 		execCtx := ctx
 		var localFiles []*os.File
+		s.setSent(statementSQL, statementOffsets[idx])
 
 		if rewrittenSQL, localPaths, isLocal := rewriteLocalCopyToSTDIN(statementSQL); isLocal {
 			execSQL = rewrittenSQL
+			s.setSent("", nil)
 			var openErr error
 			localFiles, openErr = openLocalCopyFiles(localPaths)
 			if openErr != nil {
@@ -332,22 +396,72 @@ func (s *stmt) QueryContextRaw(ctx context.Context, baseArgs []driver.NamedValue
 			vCtx := NewVerticaContext(ctx)
 			if parentCtx, ok := ctx.(VerticaContext); ok {
 				_ = vCtx.SetCopyBlockSizeBytes(parentCtx.GetCopyBlockSizeBytes())
+				_ = vCtx.SetCopyWriteFileFunc(parentCtx.GetCopyWriteFileFunc())
 			}
 			_ = vCtx.SetCopyInputStream(multiReaderFromFiles(localFiles))
 			execCtx = vCtx
 		}
 
-		resultSet, runErr := s.runSimpleStatement(execCtx, execSQL)
+		resultSet, runErr := s.runSimpleStatement(execCtx, execSQL, stream)
 		closeLocalCopyFiles(localFiles)
+		if vErr, ok := runErr.(*VError); ok && keepGoing {
+			failed = append(failed, &StatementError{Index: idx, Statement: statementSQL, Err: vErr})
+			continue
+		}
 		if runErr != nil {
 			return newEmptyRows(), runErr
 		}
+		resultSet.statement = statementSQL
+		if stream {
+			streaming = resultSet.attachStream(release)
+		}
 		resultSets = append(resultSets, resultSet)
 	}
 
+	if len(failed) > 0 {
+		return mergeRowSets(resultSets), &ScriptError{Errors: failed}
+	}
 	return mergeRowSets(resultSets), nil
 }
 
+// noticeHandler returns the notice handler the caller set for a statement, if
+// any.
+func noticeHandler(ctx context.Context) NoticeHandler {
+	if vCtx, ok := ctx.(VerticaContext); ok {
+		return vCtx.GetNoticeHandler()
+	}
+	return nil
+}
+
+// continueOnError reports whether the caller asked for a string of several
+// statements to carry on past the ones that fail.
+func continueOnError(ctx context.Context) bool {
+	if vCtx, ok := ctx.(VerticaContext); ok {
+		return vCtx.GetContinueOnError()
+	}
+	return false
+}
+
+// watchForCancel sends a cancel request to the server if ctx is done before
+// a value is sent on the returned channel.
+func (s *stmt) watchForCancel(ctx context.Context) chan<- bool {
+	doneChan := make(chan bool, 1)
+	go func(pid, key uint32) {
+		select {
+		case <-doneChan:
+			return
+		case <-ctx.Done():
+			stmtLogger.Info("Context cancelled, cancelling %s", s.preparedName)
+			if err := s.conn.cancelSession(context.Background(), pid, key); err != nil {
+				stmtLogger.Warn("unable to send cancel message: %v", err)
+				return
+			}
+			stmtLogger.Info("Cancelled %s", s.preparedName)
+		}
+	}(s.conn.backendPID, s.conn.cancelKey)
+	return doneChan
+}
+
 func (s *stmt) copySTDIN(ctx context.Context) {
 
 	var streamToUse io.Reader
@@ -432,18 +546,100 @@ func (s *stmt) copyLocalFile(ctx context.Context, fileName string) error {
 	}
 }
 
-func (s *stmt) runSimpleStatement(ctx context.Context, sql string) (*rows, error) {
+// copyFileWriter writes the files a COPY statement produces on the client,
+// keeping each one open until the statement finishes.
+type copyFileWriter struct {
+	open    CopyWriteFileFunc
+	writers map[string]io.Writer
+	err     error
+}
+
+func newCopyFileWriter(ctx context.Context) *copyFileWriter {
+	w := &copyFileWriter{open: openCopyOutputFile, writers: make(map[string]io.Writer)}
+	if vCtx, ok := ctx.(VerticaContext); ok && vCtx.GetCopyWriteFileFunc() != nil {
+		w.open = vCtx.GetCopyWriteFileFunc()
+	}
+	return w
+}
+
+// openCopyOutputFile appends to the named file. Rejected row numbers have no
+// file of their own and are discarded.
+func openCopyOutputFile(fileName string) (io.Writer, error) {
+	if fileName == "" {
+		return nil, nil
+	}
+	return os.OpenFile(filepath.Clean(fileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+}
+
+// write handles a WriteFile message. A failure is kept until the statement
+// finishes so that the rest of the server's response is still read.
+func (w *copyFileWriter) write(msg *msgs.BEWriteFileMsg) {
+	if w.err != nil {
+		return
+	}
+
+	dest, ok := w.writers[msg.FileName]
+	if !ok {
+		var err error
+		if dest, err = w.open(msg.FileName); err != nil {
+			w.err = fmt.Errorf("unable to open COPY output file '%s': %v", msg.FileName, err)
+			return
+		}
+		w.writers[msg.FileName] = dest
+	}
+	if dest == nil {
+		stmtLogger.Debug("discarding %d rejected row number(s)", len(msg.RejectedRows()))
+		return
+	}
+
+	data := msg.Data
+	if msg.FileName == "" {
+		var rowNums strings.Builder
+		for _, rowNum := range msg.RejectedRows() {
+			rowNums.WriteString(strconv.FormatUint(rowNum, 10))
+			rowNums.WriteByte('\n')
+		}
+		data = []byte(rowNums.String())
+	}
+
+	if _, err := dest.Write(data); err != nil {
+		w.err = fmt.Errorf("unable to write COPY output file '%s': %v", msg.FileName, err)
+	}
+}
+
+// Close closes the writers that need it and returns the first error seen.
+func (w *copyFileWriter) Close() error {
+	for fileName, dest := range w.writers {
+		if closer, ok := dest.(io.Closer); ok {
+			if err := closer.Close(); err != nil && w.err == nil {
+				w.err = fmt.Errorf("unable to close COPY output file '%s': %v", fileName, err)
+			}
+		}
+		delete(w.writers, fileName)
+	}
+	return w.err
+}
+
+// runSimpleStatement runs a single statement with the simple query protocol. If
+// stream is set, it returns as soon as the first row arrives and leaves the rest
+// of the result on the wire for the returned rows to read.
+func (s *stmt) runSimpleStatement(ctx context.Context, sql string, stream bool) (*rows, error) {
 	statement := strings.TrimSpace(sql)
 	if len(statement) == 0 {
 		return newEmptyRows(), nil
 	}
 
 	result := newEmptyRows()
+	copyFiles := newCopyFileWriter(ctx)
+	defer copyFiles.Close()
 
 	if err := s.conn.sendMessage(&msgs.FEQueryMsg{Query: statement}); err != nil {
 		return result, err
 	}
 
+	// The description's tag stands in until the statement completes.
+	describedTag, completedTag := "", ""
+
 	for {
 		bMsg, err := s.conn.recvMessage()
 		if err != nil {
@@ -452,14 +648,21 @@ func (s *stmt) runSimpleStatement(ctx context.Context, sql string) (*rows, error
 
 		switch msg := bMsg.(type) {
 		case *msgs.BEDataRowMsg:
+			if stream {
+				result.commandTag = describedTag
+				result.startStream(ctx, s, msg, true)
+				return result, nil
+			}
 			if err = result.addRow(msg); err != nil {
 				return result, err
 			}
 		case *msgs.BERowDescMsg:
-			result = newRows(ctx, msg, s.conn.serverTZOffset)
+			result = s.newRows(ctx, msg)
 		case *msgs.BECmdDescriptionMsg:
-			continue
-		case *msgs.BECmdCompleteMsg, *msgs.BEParseCompleteMsg:
+			describedTag = msg.CommandTag
+		case *msgs.BECmdCompleteMsg:
+			completedTag = msg.Tag
+		case *msgs.BEParseCompleteMsg:
 			continue
 		case *msgs.BEErrorMsg:
 			if drainErr := s.conn.drainUntilReady(); drainErr != nil {
@@ -469,9 +672,17 @@ func (s *stmt) runSimpleStatement(ctx context.Context, sql string) (*rows, error
 		case *msgs.BEEmptyQueryResponseMsg:
 			return newEmptyRows(), nil
 		case *msgs.BEReadyForQueryMsg, *msgs.BEPortalSuspendedMsg:
+			if err = copyFiles.Close(); err != nil {
+				return result, err
+			}
 			if err = result.finalize(); err != nil {
 				return result, err
 			}
+			result.commandTag = completedTag
+			if result.commandTag == "" {
+				result.commandTag = describedTag
+			}
+			result.notices = s.conn.takeNotices()
 			if ctxErr := ctx.Err(); ctxErr != nil {
 				return result, ctxErr
 			}
@@ -486,6 +697,8 @@ func (s *stmt) runSimpleStatement(ctx context.Context, sql string) (*rows, error
 			if err = s.copyLocalFile(ctx, msg.FileName); err != nil {
 				return newEmptyRows(), err
 			}
+		case *msgs.BEWriteFileMsg:
+			copyFiles.write(msg)
 		default:
 			s.conn.defaultMessageHandler(bMsg)
 		}
@@ -530,7 +743,7 @@ func (s *stmt) cleanQuotes(val string) string {
 	return cleaned.String()
 }
 
-func (s *stmt) formatArg(arg driver.NamedValue) string {
+func (s *stmt) formatArg(arg driver.NamedValue) (string, error) {
 	var replaceStr string
 	switch v := arg.Value.(type) {
 	case nil:
@@ -539,6 +752,13 @@ func (s *stmt) formatArg(arg driver.NamedValue) string {
 		replaceStr = fmt.Sprintf("%v", v)
 	case string:
 		replaceStr = fmt.Sprintf("'%s'", s.cleanQuotes(v))
+	case Decimal:
+		// Unvalidated text is quoted so it can only ever be a literal.
+		if v.validate() == nil {
+			replaceStr = string(v)
+		} else {
+			replaceStr = fmt.Sprintf("'%s'", s.cleanQuotes(string(v)))
+		}
 	case bool:
 		if v {
 			replaceStr = "true"
@@ -546,6 +766,10 @@ func (s *stmt) formatArg(arg driver.NamedValue) string {
 			replaceStr = "false"
 		}
 	case time.Time:
+		if text, ok := infinityText(v); ok {
+			replaceStr = fmt.Sprintf("'%s'", text)
+			break
+		}
 		replaceStr = fmt.Sprintf("'%02d-%02d-%02d %02d:%02d:%02d.%09d'",
 			v.Year(),
 			v.Month(),
@@ -554,10 +778,35 @@ func (s *stmt) formatArg(arg driver.NamedValue) string {
 			v.Minute(),
 			v.Second(),
 			v.Nanosecond())
+	case []byte:
+		replaceStr = fmt.Sprintf("X'%x'", v)
+	case Interval:
+		// An unqualified literal is read as DAY TO SECOND, which has no months.
+		text, intervalRange, err := v.encode(0)
+		if err != nil {
+			return "", err
+		}
+		replaceStr = fmt.Sprintf("INTERVAL '%s'", text)
+		if intervalRange != "" {
+			replaceStr += " " + intervalRange
+		}
+	case Time:
+		replaceStr = fmt.Sprintf("TIME '%s'", v.String())
+	case TimeTZ:
+		replaceStr = fmt.Sprintf("TIMETZ '%s'", v.String())
+	case Array:
+		elems := make([]string, len(v))
+		for idx, elem := range v {
+			var err error
+			if elems[idx], err = s.formatArg(driver.NamedValue{Ordinal: arg.Ordinal, Value: elem}); err != nil {
+				return "", err
+			}
+		}
+		replaceStr = "ARRAY[" + strings.Join(elems, ",") + "]"
 	default:
-		replaceStr = "?unknown_type?"
+		return "", fmt.Errorf("unsupported argument type %T for parameter %d", v, arg.Ordinal)
 	}
-	return replaceStr
+	return replaceStr, nil
 }
 
 func (s *stmt) interpolate(args []driver.NamedValue) (string, error) {
@@ -565,17 +814,25 @@ func (s *stmt) interpolate(args []driver.NamedValue) (string, error) {
 	numArgs := s.NumInput()
 
 	if numArgs == 0 {
+		s.argOffsets = parse.OffsetMap{}
 		return s.command, nil
 	}
 
 	curArg := 0
+	var argErr error
 	argSwapper := func() string {
-		arg := s.formatArg(args[curArg])
+		arg, err := s.formatArg(args[curArg])
+		if err != nil && argErr == nil {
+			argErr = err
+		}
 		curArg++
 		return arg
 	}
 
-	result := parse.Lex(s.command, parse.WithPositionalSubstitution(argSwapper))
+	result := parse.Lex(s.command, parse.WithPositionalSubstitution(argSwapper), parse.WithOffsetMap(&s.argOffsets))
+	if argErr != nil {
+		return "", argErr
+	}
 	return result, nil
 }
 
@@ -583,7 +840,30 @@ func (s *stmt) evaluateErrorMsg(msg *msgs.BEErrorMsg) error {
 	if msg.Severity == "ROLLBACK" {
 		s.rolledBack = true
 	}
-	return errorMsgToVError(msg)
+	return s.statementError(msg)
+}
+
+// statementError converts an error message into a *VError whose position is
+// located in the SQL the caller submitted.
+func (s *stmt) statementError(msg *msgs.BEErrorMsg) *VError {
+	vErr := errorMsgToVError(msg)
+	vErr.locate(s.query, s.sentSQL, s.queryOffset)
+	return vErr
+}
+
+// setSent records the SQL about to be sent to the server, see sentSQL and
+// sentOffsets.
+func (s *stmt) setSent(sql string, offsets *parse.OffsetMap) {
+	s.sentSQL = sql
+	s.sentOffsets = offsets
+}
+
+// queryOffset maps an offset in the SQL last sent to the server to the query.
+func (s *stmt) queryOffset(offset int) int {
+	if s.sentOffsets != nil {
+		offset = s.argOffsets.InputOffset(s.sentOffsets.InputOffset(offset))
+	}
+	return s.lexOffsets.InputOffset(offset)
 }
 
 // isLocalCopyStatement reports whether the statement is a COPY ... FROM LOCAL ...
@@ -653,6 +933,7 @@ type sqlToken struct {
 	text  string
 	start int
 	end   int
+	depth int // how many parentheses the token is nested in
 }
 
 type localCopyAnalysis struct {
@@ -665,13 +946,14 @@ func topLevelSQLTokens(statement string) []sqlToken {
 	tokens := make([]sqlToken, 0, 16)
 	var current strings.Builder
 	tokenStart := -1
+	depth := 0
 	// Tokenize only top-level SQL words and ignore quoted/commented regions so
 	// keywords inside literals/comments do not affect LOCAL COPY detection.
 	flushCurrent := func() {
 		if current.Len() == 0 {
 			return
 		}
-		tokens = append(tokens, sqlToken{text: strings.ToUpper(current.String()), start: tokenStart, end: tokenStart + current.Len()})
+		tokens = append(tokens, sqlToken{text: strings.ToUpper(current.String()), start: tokenStart, end: tokenStart + current.Len(), depth: depth})
 		current.Reset()
 		tokenStart = -1
 	}
@@ -784,6 +1066,12 @@ func topLevelSQLTokens(statement string) []sqlToken {
 		}
 
 		flushCurrent()
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
 	}
 
 	flushCurrent()
@@ -919,6 +1207,29 @@ func isBinaryOrCompressedFormat(statement string) bool {
 	return false
 }
 
+// writesClientSideFiles reports whether the options of a LOCAL COPY send
+// rejected rows or exceptions to a file, e.g. REJECTED DATA 'rejects.txt', as
+// opposed to a table with REJECTED DATA AS TABLE.
+func writesClientSideFiles(copyOptions string) bool {
+	tokens := topLevelSQLTokens(copyOptions)
+	for idx, token := range tokens {
+		end := -1
+		switch {
+		case token.text == "REJECTED" && idx+1 < len(tokens) && tokens[idx+1].text == "DATA":
+			end = tokens[idx+1].end
+		case token.text == "EXCEPTIONS":
+			end = token.end
+		}
+		if end < 0 {
+			continue
+		}
+		if pos := skipSQLTrivia(copyOptions, end); pos < len(copyOptions) && copyOptions[pos] == '\'' {
+			return true
+		}
+	}
+	return false
+}
+
 func rewriteLocalCopyToSTDIN(statement string) (string, []string, bool) {
 	analysis, ok := analyzeLocalCopyStatement(statement)
 	if !ok {
@@ -929,6 +1240,11 @@ func rewriteLocalCopyToSTDIN(statement string) (string, []string, bool) {
 	if len(analysis.paths) > 1 && isBinaryOrCompressedFormat(statement) {
 		return statement, nil, false
 	}
+	// Rejected data and exceptions files named in a LOCAL COPY are written on
+	// the client. Reading from STDIN would move them to the server instead.
+	if writesClientSideFiles(statement[analysis.suffixStart:]) {
+		return statement, nil, false
+	}
 	// Keep the original suffix exactly as-is (including spacing/comments) to
 	// avoid collapsing tokens such as STDINPARSER or STDINDELIMITER.
 	rewritten := statement[:analysis.fromStart] + "FROM STDIN" + statement[analysis.suffixStart:]
@@ -977,6 +1293,7 @@ func (s *stmt) prepareAndDescribe() error {
 	}
 
 	s.parseState = parseStateParseError
+	s.setSent(s.command, nil)
 
 	s.conn.lockSessionMutex()
 	defer s.conn.unlockSessionMutex()
@@ -1005,9 +1322,10 @@ func (s *stmt) prepareAndDescribe() error {
 		switch msg := bMsg.(type) {
 		case *msgs.BEErrorMsg:
 			s.conn.sync()
-			return errorMsgToVError(msg)
+			return s.statementError(msg)
 		case *msgs.BEParseCompleteMsg:
 			s.parseState = parseStateParsed
+			s.generation = s.conn.generation
 		case *msgs.BERowDescMsg:
 			s.lastRowDesc = msg
 			return nil
@@ -1017,14 +1335,46 @@ func (s *stmt) prepareAndDescribe() error {
 		case *msgs.BEParameterDescMsg:
 			s.paramTypes = msg.ParameterTypes
 		case *msgs.BECmdDescriptionMsg:
-			continue
+			s.describedTag = msg.CommandTag
 		default:
 			s.conn.defaultMessageHandler(msg)
 		}
 	}
 }
 
-func (s *stmt) bindAndExecute(portalName string, args []driver.NamedValue) error {
+// bindAndExecute binds args to the prepared statement and executes it. A
+// rowLimit of 0 asks the server for every row at once, and nil resultFormats
+// asks for every column as text.
+func (s *stmt) bindAndExecute(portalName string, args []driver.NamedValue, rowLimit uint32, resultFormats []uint16) error {
+
+	bind, err := s.bindMessage(portalName, args, resultFormats)
+	if err != nil {
+		return err
+	}
+
+	if err := s.conn.sendMessage(bind); err != nil {
+		return err
+	}
+
+	if err := s.conn.sendMessage(&msgs.FEExecuteMsg{Portal: portalName, RowLimit: rowLimit}); err != nil {
+		return err
+	}
+
+	if err := s.conn.sendMessage(&msgs.FEFlushMsg{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMessage encodes args for the described parameters of the statement.
+// With binary_parameters, arguments whose Go type matches their parameter
+// type are sent in the binary format.
+func (s *stmt) bindMessage(portalName string, args []driver.NamedValue, resultFormats []uint16) (*msgs.FEBindMsg, error) {
+	bindArgs, err := encodeBindArgs(args, s.paramTypes)
+	if err != nil {
+		return nil, err
+	}
 
 	// We only need to send the OID types
 	paramOIDs := make([]int32, len(s.paramTypes))
@@ -1032,11 +1382,73 @@ func (s *stmt) bindAndExecute(portalName string, args []driver.NamedValue) error
 		paramOIDs[i] = int32(p.TypeOID)
 	}
 
-	if err := s.conn.sendMessage(&msgs.FEBindMsg{Portal: portalName, Statement: s.preparedName, NamedArgs: args, OIDTypes: paramOIDs}); err != nil {
-		return err
+	var paramFormats []uint16
+	if s.conn.config.BinaryParameters {
+		formats := make([]uint16, len(bindArgs))
+		anyBinary := false
+		for idx := range bindArgs {
+			if idx >= len(s.paramTypes) {
+				break
+			}
+			if b, ok := encodeBinaryParam(bindArgs[idx].Value, s.paramTypes[idx].TypeOID); ok {
+				bindArgs[idx].Value = b
+				formats[idx] = formatBinary
+				anyBinary = true
+			}
+		}
+		if anyBinary {
+			paramFormats = formats
+		}
+	}
+
+	return &msgs.FEBindMsg{
+		Portal:        portalName,
+		Statement:     s.preparedName,
+		NamedArgs:     bindArgs,
+		OIDTypes:      paramOIDs,
+		ParamFormats:  paramFormats,
+		ResultFormats: resultFormats,
+	}, nil
+}
+
+// resultFormats returns the result format codes to bind with, or nil for text.
+// Binary is requested per column, when enabled on the connection or on a
+// VerticaContext, for the types that have a binary decoder.
+func (s *stmt) resultFormats(ctx context.Context) []uint16 {
+	binaryResults := s.conn.config.BinaryResults
+	if vCtx, ok := ctx.(VerticaContext); ok {
+		binaryResults = binaryResults || vCtx.GetBinaryResults()
+	}
+	if !binaryResults || s.lastRowDesc == nil {
+		return nil
 	}
+	formats := binaryResultFormats(s.lastRowDesc)
+	// Registered decoders read the text form.
+	for idx := range formats {
+		if s.conn.config.Types.lookupDecoder(s.lastRowDesc.Columns[idx]) != nil {
+			formats[idx] = formatText
+		}
+	}
+	return formats
+}
 
-	if err := s.conn.sendMessage(&msgs.FEExecuteMsg{Portal: portalName}); err != nil {
+// fetchSize returns the number of rows to request per Execute, preferring the
+// value set on a VerticaContext over the connection's fetch_size.
+func (s *stmt) fetchSize(ctx context.Context) uint32 {
+	if vCtx, ok := ctx.(VerticaContext); ok && vCtx.GetFetchSize() > 0 {
+		return uint32(vCtx.GetFetchSize())
+	}
+	return uint32(s.conn.config.FetchSize)
+}
+
+// closePortal releases a named portal on the server, discarding any rows it
+// has not yet returned. The unnamed portal needs no cleanup.
+func (s *stmt) closePortal(portalName string) error {
+	if portalName == "" {
+		return nil
+	}
+
+	if err := s.conn.sendMessage(&msgs.FECloseMsg{TargetType: msgs.CmdTargetTypePortal, TargetName: portalName}); err != nil {
 		return err
 	}
 
@@ -1044,15 +1456,54 @@ func (s *stmt) bindAndExecute(portalName string, args []driver.NamedValue) error
 		return err
 	}
 
-	return nil
+	for {
+		bMsg, err := s.conn.recvMessage()
+		if err != nil {
+			return err
+		}
+
+		switch bMsg.(type) {
+		case *msgs.BECloseCompleteMsg:
+			return nil
+		case *msgs.BECmdDescriptionMsg:
+			continue
+		default:
+			_, _ = s.conn.defaultMessageHandler(bMsg)
+		}
+	}
 }
 
-func (s *stmt) collectResults(ctx context.Context) (*rows, error) {
+// newRows creates the rows for a result of this statement, applying the
+// decoding options of the connection and of a VerticaContext.
+func (s *stmt) newRows(ctx context.Context, columnDefs *msgs.BERowDescMsg) *rows {
+	r := newRows(ctx, columnDefs, s.conn.serverLocation)
+	r.types = s.conn.config.Types
+	r.exactNumeric = s.conn.config.ExactNumeric
+	r.typedIntervals = s.conn.config.TypedIntervals
+	r.naiveTimestamps = s.conn.config.NaiveTimestamps
+	r.typedTime = s.conn.config.TypedTime
+	if vCtx, ok := ctx.(VerticaContext); ok {
+		r.exactNumeric = r.exactNumeric || vCtx.GetExactNumeric()
+		r.typedIntervals = r.typedIntervals || vCtx.GetTypedIntervals()
+		r.naiveTimestamps = r.naiveTimestamps || vCtx.GetNaiveTimestamps()
+		r.typedTime = r.typedTime || vCtx.GetTypedTime()
+		r.lenient = vCtx.GetLenientDecoding()
+	}
+	if vCtx, ok := ctx.(*verticaContext); ok && r.lenient {
+		r.decodeErrs = &vCtx.decodeErrs
+	}
+	return r
+}
+
+func (s *stmt) collectResults(ctx context.Context, portalName string, fetchSize uint32, resultFormats []uint16) (*rows, error) {
 	rows := newEmptyRows()
 
 	if s.lastRowDesc != nil {
-		rows = newRows(ctx, s.lastRowDesc, s.conn.serverTZOffset)
+		rows = s.newRows(ctx, withResultFormats(s.lastRowDesc, resultFormats))
 	}
+	rows.commandTag = s.describedTag
+	copyFiles := newCopyFileWriter(ctx)
+	defer copyFiles.Close()
 
 	for {
 		bMsg, err := s.conn.recvMessage()
@@ -1069,13 +1520,19 @@ func (s *stmt) collectResults(ctx context.Context) (*rows, error) {
 			// whenever a wider DataRow is seen so that Columns() always returns
 			// the correct width.
 			//
-			// This is safe because collectResults() is synchronous and fully
-			// buffers all rows before returning *rows to the caller. database/sql
-			// calls Columns() only after receiving that object, so all expansions
-			// are complete by the time the column list is observed.
+			// When buffering this is safe because all rows are read before *rows
+			// is returned to the caller, and database/sql calls Columns() only
+			// after receiving that object. A streamed result is handed over on
+			// its first row, so only that row can widen the column list; rows.Next
+			// rejects any later row that is wider still.
 			if uint16(len(rows.columnDefs.Columns)) < msg.Columns().NumCols {
 				rows.expandColumnDefs(msg.Columns().NumCols)
 			}
+			if portalName != "" || streamResults(ctx) {
+				rows.startStream(ctx, s, msg, false)
+				rows.stream.pageThrough(portalName, fetchSize)
+				return rows, nil
+			}
 			err = rows.addRow(msg)
 			if err != nil {
 				return rows, err
@@ -1087,16 +1544,28 @@ func (s *stmt) collectResults(ctx context.Context) (*rows, error) {
 			// truncated description from silently replacing a wider one.
 			if rows.resultData.Peek() == nil && len(msg.Columns) >= len(rows.columnDefs.Columns) {
 				s.lastRowDesc = msg
-				rows = newRows(ctx, s.lastRowDesc, s.conn.serverTZOffset)
+				rows = s.newRows(ctx, withResultFormats(s.lastRowDesc, resultFormats))
+				rows.commandTag = s.describedTag
 			}
 		case *msgs.BEErrorMsg:
 			s.conn.sync()
+			_ = s.closePortal(portalName)
 			return newEmptyRows(), s.evaluateErrorMsg(msg)
 		case *msgs.BEEmptyQueryResponseMsg:
-			return newEmptyRows(), nil
+			return newEmptyRows(), s.closePortal(portalName)
 		case *msgs.BEBindCompleteMsg, *msgs.BECmdDescriptionMsg:
 			continue
 		case *msgs.BEReadyForQueryMsg, *msgs.BEPortalSuspendedMsg, *msgs.BECmdCompleteMsg:
+			if complete, ok := msg.(*msgs.BECmdCompleteMsg); ok && complete.Tag != "" {
+				rows.commandTag = complete.Tag
+			}
+			rows.notices = s.conn.takeNotices()
+			if err = s.closePortal(portalName); err != nil {
+				return rows, err
+			}
+			if err = copyFiles.Close(); err != nil {
+				return rows, err
+			}
 			err = rows.finalize()
 			if err != nil {
 				return rows, err
@@ -1112,6 +1581,8 @@ func (s *stmt) collectResults(ctx context.Context) (*rows, error) {
 			if err = s.copyLocalFile(ctx, msg.FileName); err != nil {
 				return newEmptyRows(), err
 			}
+		case *msgs.BEWriteFileMsg:
+			copyFiles.write(msg)
 		default:
 			_, _ = s.conn.defaultMessageHandler(msg)
 		}
@@ -34,14 +34,24 @@ package vertigo
 
 import (
 	"bytes"
+	"context"
 	"database/sql/driver"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/vertica/vertica-sql-go/common"
 	"github.com/vertica/vertica-sql-go/msgs"
+	"github.com/vertica/vertica-sql-go/parse"
 )
 
 func testStatement(command string) *stmt {
@@ -111,6 +121,54 @@ func TestInterpolate(t *testing.T) {
 			expected: "select * from something where value = 'it''s other''s'",
 			args:     []driver.NamedValue{{Value: "it''s other''s"}},
 		},
+		{
+			name:     "exact decimal",
+			command:  "select * from something where value = ?",
+			expected: "select * from something where value = -1234567890123456789.0123456789",
+			args:     []driver.NamedValue{{Value: Decimal("-1234567890123456789.0123456789")}},
+		},
+		{
+			name:     "malformed decimal stays a literal",
+			command:  "select * from something where value = ?",
+			expected: "select * from something where value = '1; drop table x'",
+			args:     []driver.NamedValue{{Value: Decimal("1; drop table x")}},
+		},
+		{
+			name:     "bytes become a hex literal",
+			command:  "select * from something where value = ?",
+			expected: "select * from something where value = X'00ff27'",
+			args:     []driver.NamedValue{{Value: []byte{0x00, 0xff, 0x27}}},
+		},
+		{
+			name:     "interval",
+			command:  "select * from something where value = ?",
+			expected: "select * from something where value = INTERVAL '1 year 2 months ago' YEAR TO MONTH",
+			args:     []driver.NamedValue{{Value: Interval{Months: -14}}},
+		},
+		{
+			name:     "day-time interval",
+			command:  "select * from something where value = ?",
+			expected: "select * from something where value = INTERVAL '3 days 4 hours'",
+			args:     []driver.NamedValue{{Value: Interval{Days: 3, Microseconds: 4 * microsPerHour}}},
+		},
+		{
+			name:     "times of day",
+			command:  "select * from something where opens = ? and offset = ?",
+			expected: "select * from something where opens = TIME '08:30:00.25' and offset = TIMETZ '08:30:00-05'",
+			args:     []driver.NamedValue{{Value: Time{Hour: 8, Minute: 30, Microsecond: 250000}}, {Value: TimeTZ{Time: Time{Hour: 8, Minute: 30}, Offset: -5 * 3600}}},
+		},
+		{
+			name:     "infinity timestamps",
+			command:  "select * from something where value between ? and ?",
+			expected: "select * from something where value between '-infinity' and 'infinity'",
+			args:     []driver.NamedValue{{Value: NegativeInfinity}, {Value: PositiveInfinity}},
+		},
+		{
+			name:     "array becomes a constructor",
+			command:  "select * from something where value = ANY(?)",
+			expected: "select * from something where value = ANY(ARRAY[1,NULL,'it''s',ARRAY[true]])",
+			args:     []driver.NamedValue{{Value: Array{int64(1), nil, "it's", Array{true}}}},
+		},
 		{
 			name:     "with a param looking rune in a string",
 			command:  "select * from something where value = ? and test = '?bad'",
@@ -132,6 +190,22 @@ func TestInterpolate(t *testing.T) {
 	}
 }
 
+func TestInterpolateUnsupportedType(t *testing.T) {
+	stmt := testStatement("select * from something where value = ?")
+	_, err := stmt.interpolate([]driver.NamedValue{{Ordinal: 1, Value: struct{}{}}})
+	if err == nil || !strings.Contains(err.Error(), "unsupported argument type struct {} for parameter 1") {
+		t.Errorf("expected an unsupported type error, got %v", err)
+	}
+}
+
+func TestInterpolateMixedInterval(t *testing.T) {
+	stmt := testStatement("select * from something where value = ?")
+	_, err := stmt.interpolate([]driver.NamedValue{{Ordinal: 1, Value: Interval{Months: 1, Days: 2}}})
+	if err == nil || !strings.Contains(err.Error(), "mixes months with days or seconds") {
+		t.Errorf("expected a mixed interval error, got %v", err)
+	}
+}
+
 func TestCleanQuotes(t *testing.T) {
 	var testCases = []struct {
 		name     string
@@ -404,6 +478,27 @@ func TestRewriteLocalCopyToSTDINVariants(t *testing.T) {
 			expectedPaths: []string{"/tmp/f1.avro"},
 			expectedLocal: true,
 		},
+		{
+			name:          "client side rejected data keeps local",
+			query:         "COPY t1 FROM LOCAL '/tmp/f1.csv' DELIMITER ',' REJECTED DATA '/tmp/rejects.txt';",
+			expectedSQL:   "COPY t1 FROM LOCAL '/tmp/f1.csv' DELIMITER ',' REJECTED DATA '/tmp/rejects.txt';",
+			expectedPaths: nil,
+			expectedLocal: false,
+		},
+		{
+			name:          "client side exceptions keeps local",
+			query:         "COPY t1 FROM LOCAL '/tmp/f1.csv' EXCEPTIONS /*log*/ '/tmp/exceptions.txt';",
+			expectedSQL:   "COPY t1 FROM LOCAL '/tmp/f1.csv' EXCEPTIONS /*log*/ '/tmp/exceptions.txt';",
+			expectedPaths: nil,
+			expectedLocal: false,
+		},
+		{
+			name:          "rejected data mentioned in a literal",
+			query:         "COPY t1 FROM LOCAL '/tmp/f1.csv' DELIMITER ',' NULL 'REJECTED DATA ''x''';",
+			expectedSQL:   "COPY t1 FROM STDIN DELIMITER ',' NULL 'REJECTED DATA ''x''';",
+			expectedPaths: []string{"/tmp/f1.csv"},
+			expectedLocal: true,
+		},
 		{
 			name:          "preserve comment between list and clause",
 			query:         "COPY t1 FROM LOCAL '/tmp/f1.csv' /*after*/ DELIMITER ',';",
@@ -637,3 +732,158 @@ func (t *testRowStore) Close() error {
 }
 
 func (t *testRowStore) Finalize() error { return nil }
+
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closingBuffer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestCopyFileWriter(t *testing.T) {
+	outputs := map[string]*closingBuffer{}
+	vCtx := NewVerticaContext(context.Background())
+	_ = vCtx.SetCopyWriteFileFunc(func(fileName string) (io.Writer, error) {
+		if fileName == "denied.txt" {
+			return nil, errors.New("permission denied")
+		}
+		outputs[fileName] = &closingBuffer{}
+		return outputs[fileName], nil
+	})
+
+	w := newCopyFileWriter(vCtx)
+	w.write(&msgs.BEWriteFileMsg{FileName: "rejects.txt", Data: []byte("bad,row\n")})
+	w.write(&msgs.BEWriteFileMsg{FileName: "exceptions.txt", Data: []byte("COPY: bad input\n")})
+	w.write(&msgs.BEWriteFileMsg{FileName: "rejects.txt", Data: []byte("worse,row\n")})
+	w.write(&msgs.BEWriteFileMsg{Data: []byte{0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 7}})
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"rejects.txt":    "bad,row\nworse,row\n",
+		"exceptions.txt": "COPY: bad input\n",
+		"":               "2\n7\n",
+	}
+	for fileName, contents := range expected {
+		out, ok := outputs[fileName]
+		if !ok {
+			t.Fatalf("nothing written to %q", fileName)
+		}
+		if out.String() != contents || !out.closed {
+			t.Errorf("expected %q to hold %q and be closed, got %q (closed=%v)", fileName, contents, out.String(), out.closed)
+		}
+	}
+
+	w = newCopyFileWriter(vCtx)
+	w.write(&msgs.BEWriteFileMsg{FileName: "denied.txt", Data: []byte("x")})
+	if err := w.Close(); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected the open failure to be reported, got %v", err)
+	}
+}
+
+func TestCopyFileWriterDefault(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rejects.txt")
+	if err := ioutil.WriteFile(fileName, []byte("existing\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	w := newCopyFileWriter(context.Background())
+	w.write(&msgs.BEWriteFileMsg{FileName: fileName, Data: []byte("appended\n")})
+	w.write(&msgs.BEWriteFileMsg{Data: []byte{0, 0, 0, 0, 0, 0, 0, 1}})
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contents, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != "existing\nappended\n" {
+		t.Errorf("unexpected file contents %q", contents)
+	}
+}
+
+func TestStatementErrorPosition(t *testing.T) {
+	// position returns the server's 1-based character position of token in sent.
+	position := func(sent, token string) string {
+		return strconv.Itoa(utf8.RuneCountInString(sent[:strings.Index(sent, token)]) + 1)
+	}
+
+	// Prepared: the named parameter became a ? in the command sent.
+	query := "SELECT 'é', *\nFROM t\nWHERE a = @first AND\tb = @second AND oops > 1"
+	stmt := testStatement(query)
+	stmt.setSent(stmt.command, nil)
+	vErr := stmt.statementError(&msgs.BEErrorMsg{Severity: "ERROR", ErrorCode: "4856", SQLState: "42601",
+		Message: `Syntax error at or near "oops"`, Position: position(stmt.command, "oops"), Hint: "check the query"})
+	assertEqual(t, vErr.Statement, query)
+	assertEqual(t, vErr.StatementLine, 3)
+	assertEqual(t, vErr.StatementColumn, strings.Index("WHERE a = @first AND\tb = @second AND oops", "oops")+1)
+	assertEqual(t, vErr.Verbose(), "ERROR 4856: [42601] Syntax error at or near \"oops\"\n"+
+		"LINE 3: WHERE a = @first AND\tb = @second AND oops > 1\n"+
+		"        "+"                    \t"+"                ^\n"+
+		"HINT: check the query")
+	assertEqual(t, fmt.Sprintf("%+v", vErr), vErr.Verbose())
+	assertEqual(t, fmt.Sprintf("%v", vErr), vErr.Error())
+
+	// Simple: the arguments were interpolated and the script split into
+	// statements, dropping the comments.
+	query = "CREATE TABLE t (a varchar);\n-- the failing one\nINSERT INTO t -- note\nSELECT @first || 'é' FROM s WHERE oops"
+	stmt = testStatement(query)
+	interpolated, err := stmt.interpolate([]driver.NamedValue{{Name: "first", Value: "a much longer value"}})
+	assertNoErr(t, err)
+	statements, offsets := parse.SplitStatementsMapped(interpolated)
+	assertEqual(t, len(statements), 2)
+	stmt.setSent(statements[1], offsets[1])
+	vErr = stmt.statementError(&msgs.BEErrorMsg{Position: position(statements[1], "oops")})
+	assertEqual(t, vErr.StatementLine, 4)
+	assertEqual(t, vErr.StatementColumn, utf8.RuneCountInString("SELECT @first || 'é' FROM s WHERE ")+1)
+
+	// Without a position the error is not located.
+	vErr = stmt.statementError(&msgs.BEErrorMsg{Message: "no position"})
+	assertEqual(t, vErr.Statement, "")
+	assertEqual(t, vErr.StatementLine, 0)
+}
+
+func TestContinueOnErrorResults(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	stmt := testStatement("INSERT INTO t VALUES (1); INSERT INTO missing VALUES (2)")
+	stmt.conn = &connection{conn: client}
+	vCtx := NewVerticaContext(context.Background())
+	assertNoErr(t, vCtx.SetContinueOnError(true))
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			readFrontEndMsg(t, server)
+			writeBackEndMsg(t, server, 'C', []byte("INSERT 0 1\x00"))
+			writeBackEndMsg(t, server, 'Z', []byte("I"))
+			readFrontEndMsg(t, server)
+			writeBackEndMsg(t, server, 'E', []byte("SERROR\x00C42V01\x00MTable missing does not exist\x00\x00"))
+			writeBackEndMsg(t, server, 'Z', []byte("I"))
+		}
+	}()
+
+	// An Exec reports the statements that succeeded along with the error.
+	res, err := stmt.ExecContext(vCtx, nil)
+	if _, ok := err.(*ScriptError); !ok {
+		t.Fatalf("expected a *ScriptError, got %v", err)
+	}
+	affected, _ := res.RowsAffected()
+	assertEqual(t, affected, int64(1))
+
+	// A Query returns no rows, as database/sql would not close them.
+	rows, err := stmt.QueryContext(vCtx, nil)
+	if _, ok := err.(*ScriptError); !ok {
+		t.Fatalf("expected a *ScriptError, got %v", err)
+	}
+	assertEqual(t, len(rows.Columns()), 0)
+	if err := rows.Next(make([]driver.Value, 1)); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
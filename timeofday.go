@@ -0,0 +1,320 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time is a Vertica TIME value, a time of day without a date or time zone.
+// With typed_time enabled, TIME columns are returned as Time rather than as a
+// time.Time on January 1st of year 0. A Time can be used as a query argument.
+type Time struct {
+	Hour        int
+	Minute      int
+	Second      int
+	Microsecond int
+}
+
+// TimeTZ is a Vertica TIMETZ value, a time of day with a UTC offset. With
+// typed_time enabled, TIMETZ columns are returned as TimeTZ.
+type TimeTZ struct {
+	Time
+	Offset int // seconds east of UTC
+}
+
+// TimeOf returns the time of day of the wall clock of t.
+func TimeOf(t time.Time) Time {
+	return Time{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Microsecond: t.Nanosecond() / 1000}
+}
+
+// TimeTZOf returns the time of day and the UTC offset of t.
+func TimeTZOf(t time.Time) TimeTZ {
+	_, offset := t.Zone()
+	return TimeTZ{Time: TimeOf(t), Offset: offset}
+}
+
+// timeSinceMidnight returns the time of day d after midnight.
+func timeSinceMidnight(d time.Duration) Time {
+	micros := d.Microseconds()
+	return Time{
+		Hour:        int(micros / microsPerHour),
+		Minute:      int(micros % microsPerHour / microsPerMinute),
+		Second:      int(micros % microsPerMinute / microsPerSecond),
+		Microsecond: int(micros % microsPerSecond),
+	}
+}
+
+// SinceMidnight returns the time elapsed since midnight.
+func (t Time) SinceMidnight() time.Duration {
+	micros := int64(t.Hour)*microsPerHour + int64(t.Minute)*microsPerMinute +
+		int64(t.Second)*microsPerSecond + int64(t.Microsecond)
+	return time.Duration(micros) * time.Microsecond
+}
+
+// String returns the time in the form the server prints it, such as
+// "08:30:00" or "08:30:00.25".
+func (t Time) String() string {
+	text := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Microsecond != 0 {
+		text += strings.TrimRight(fmt.Sprintf(".%06d", t.Microsecond), "0")
+	}
+	return text
+}
+
+// Value returns the time literal.
+// Interface: driver.Valuer
+func (t Time) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan reads a Time, the text the server prints for a time, or the wall clock
+// of a time.Time. Use NullTime for nullable columns.
+// Interface: sql.Scanner
+func (t *Time) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case Time:
+		*t = v
+		return nil
+	case time.Time:
+		*t = TimeOf(v)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Time, use NullTime instead")
+	}
+	return fmt.Errorf("cannot scan %T into Time", src)
+}
+
+// MarshalText encodes the time as its String form, which is also used for JSON.
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses a time such as "08:30:00.25".
+func (t *Time) UnmarshalText(text []byte) error {
+	val, err := parseTime(string(text))
+	if err != nil {
+		return err
+	}
+	*t = val
+	return nil
+}
+
+// String returns the time with its offset in the form the server prints it,
+// such as "08:30:00-05" or "08:30:00.25+05:30".
+func (t TimeTZ) String() string {
+	sign, offset := '+', t.Offset
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	text := fmt.Sprintf("%s%c%02d", t.Time, sign, offset/3600)
+	if offset%3600 != 0 {
+		text += fmt.Sprintf(":%02d", offset%3600/60)
+	}
+	if offset%60 != 0 {
+		text += fmt.Sprintf(":%02d", offset%60)
+	}
+	return text
+}
+
+// Value returns the time literal.
+// Interface: driver.Valuer
+func (t TimeTZ) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan reads a TimeTZ, the text the server prints for a time with a time zone,
+// or the wall clock and offset of a time.Time. Use NullTimeTZ for nullable
+// columns.
+// Interface: sql.Scanner
+func (t *TimeTZ) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case TimeTZ:
+		*t = v
+		return nil
+	case time.Time:
+		*t = TimeTZOf(v)
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into TimeTZ, use NullTimeTZ instead")
+	}
+	return fmt.Errorf("cannot scan %T into TimeTZ", src)
+}
+
+// MarshalText encodes the time as its String form, which is also used for JSON.
+func (t TimeTZ) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses a time with an offset such as "08:30:00-05".
+func (t *TimeTZ) UnmarshalText(text []byte) error {
+	val, err := parseTimeTZ(string(text))
+	if err != nil {
+		return err
+	}
+	*t = val
+	return nil
+}
+
+// NullTime is a Time that may be NULL.
+type NullTime struct {
+	Time  Time
+	Valid bool // Valid is true if Time is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullTime) Scan(src interface{}) error {
+	if src == nil {
+		n.Time, n.Valid = Time{}, false
+		return nil
+	}
+	if err := n.Time.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time.Value()
+}
+
+// NullTimeTZ is a TimeTZ that may be NULL.
+type NullTimeTZ struct {
+	TimeTZ TimeTZ
+	Valid  bool // Valid is true if TimeTZ is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullTimeTZ) Scan(src interface{}) error {
+	if src == nil {
+		n.TimeTZ, n.Valid = TimeTZ{}, false
+		return nil
+	}
+	if err := n.TimeTZ.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTimeTZ) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.TimeTZ.Value()
+}
+
+// parseTime parses the text the server prints for a TIME, hh:mm:ss with an
+// optional fraction of up to six digits. 24:00:00 is the end of the day.
+func parseTime(text string) (Time, error) {
+	var t Time
+	fields := strings.Split(strings.TrimSpace(text), ":")
+	if len(fields) != 3 {
+		return Time{}, fmt.Errorf("invalid time %q", text)
+	}
+	seconds, fraction, _ := strings.Cut(fields[2], ".")
+	var err error
+	if t.Hour, err = parseTimeField(fields[0], 24); err == nil {
+		if t.Minute, err = parseTimeField(fields[1], 59); err == nil {
+			t.Second, err = parseTimeField(seconds, 59)
+		}
+	}
+	if err == nil && fraction != "" {
+		var micros int64
+		micros, err = parseFraction(fraction)
+		t.Microsecond = int(micros)
+	}
+	if err == nil && t.Hour == 24 && (t.Minute != 0 || t.Second != 0 || t.Microsecond != 0) {
+		err = fmt.Errorf("past the end of the day")
+	}
+	if err != nil {
+		return Time{}, fmt.Errorf("invalid time %q: %v", text, err)
+	}
+	return t, nil
+}
+
+// parseTimeTZ parses the text the server prints for a TIMETZ, a time followed
+// by an offset of hours and optional minutes and seconds, e.g. "08:30:00+05:30".
+func parseTimeTZ(text string) (TimeTZ, error) {
+	s := strings.TrimSpace(text)
+	idx := strings.LastIndexAny(s, "+-")
+	if idx < 0 {
+		return TimeTZ{}, fmt.Errorf("invalid time with time zone %q: missing offset", text)
+	}
+	t, err := parseTime(s[:idx])
+	if err != nil {
+		return TimeTZ{}, err
+	}
+
+	var offset int
+	for i, field := range strings.Split(s[idx+1:], ":") {
+		n, err := parseTimeField(field, 59)
+		if err != nil || i > 2 || (i == 0 && len(field) != 2) {
+			return TimeTZ{}, fmt.Errorf("invalid time with time zone %q: bad offset", text)
+		}
+		offset += n * []int{3600, 60, 1}[i]
+	}
+	if s[idx] == '-' {
+		offset = -offset
+	}
+	return TimeTZ{Time: t, Offset: offset}, nil
+}
+
+func parseTimeField(s string, max int) (int, error) {
+	if len(s) != 2 || strings.Trim(s, "0123456789") != "" {
+		return 0, fmt.Errorf("bad field %q", s)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > max {
+		return 0, fmt.Errorf("bad field %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,193 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+func TestParseTime(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected Time
+	}{
+		{text: "08:30:00", expected: Time{Hour: 8, Minute: 30}},
+		{text: "23:59:59.999999", expected: Time{Hour: 23, Minute: 59, Second: 59, Microsecond: 999999}},
+		{text: "00:00:01.5", expected: Time{Second: 1, Microsecond: 500000}},
+		{text: "24:00:00", expected: Time{Hour: 24}},
+	}
+	for _, tc := range testCases {
+		result, err := parseTime(tc.text)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.text, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%s: expected %+v, got %+v", tc.text, tc.expected, result)
+		}
+		if back := result.String(); back != tc.text {
+			t.Errorf("expected %s to format as itself, got %s", tc.text, back)
+		}
+	}
+
+	for _, text := range []string{"", "8:30:00", "08:30", "25:00:00", "24:00:01", "08:60:00", "08:30:+1", "08:30:00.x"} {
+		if _, err := parseTime(text); err == nil {
+			t.Errorf("expected %q to be rejected", text)
+		}
+	}
+}
+
+func TestParseTimeTZ(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected TimeTZ
+	}{
+		{text: "08:30:00-05", expected: TimeTZ{Time: Time{Hour: 8, Minute: 30}, Offset: -5 * 3600}},
+		{text: "08:30:00.25+05:30", expected: TimeTZ{Time: Time{Hour: 8, Minute: 30, Microsecond: 250000}, Offset: 5*3600 + 30*60}},
+		{text: "23:00:00+00", expected: TimeTZ{Time: Time{Hour: 23}}},
+		{text: "12:00:00-00:44:30", expected: TimeTZ{Time: Time{Hour: 12}, Offset: -(44*60 + 30)}},
+	}
+	for _, tc := range testCases {
+		result, err := parseTimeTZ(tc.text)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.text, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%s: expected %+v, got %+v", tc.text, tc.expected, result)
+		}
+		if back := result.String(); back != tc.text {
+			t.Errorf("expected %s to format as itself, got %s", tc.text, back)
+		}
+	}
+
+	for _, text := range []string{"08:30:00", "08:30:00+5", "08:30:00+05:30:00:00", "x-05"} {
+		if _, err := parseTimeTZ(text); err == nil {
+			t.Errorf("expected %q to be rejected", text)
+		}
+	}
+}
+
+func TestTimeOfDayValues(t *testing.T) {
+	tm := Time{Hour: 8, Minute: 30, Second: 1, Microsecond: 2}
+	if d := tm.SinceMidnight(); d != 8*time.Hour+30*time.Minute+time.Second+2*time.Microsecond {
+		t.Errorf("unexpected duration %v", d)
+	}
+	if back := timeSinceMidnight(tm.SinceMidnight()); back != tm {
+		t.Errorf("expected %+v, got %+v", tm, back)
+	}
+
+	zone := time.FixedZone("", 3600)
+	if got := TimeTZOf(time.Date(2024, 1, 1, 8, 30, 1, 2500, zone)); got != (TimeTZ{Time: tm, Offset: 3600}) {
+		t.Errorf("unexpected TimeTZOf result %+v", got)
+	}
+
+	var scanned Time
+	if err := scanned.Scan("08:30:01.000002"); err != nil || scanned != tm {
+		t.Errorf("unexpected scan result %+v, %v", scanned, err)
+	}
+	if err := scanned.Scan(time.Date(0, 1, 1, 8, 30, 1, 2000, time.UTC)); err != nil || scanned != tm {
+		t.Errorf("unexpected scan result %+v, %v", scanned, err)
+	}
+	if err := scanned.Scan(nil); err == nil {
+		t.Errorf("expected NULL to be rejected")
+	}
+	var null NullTimeTZ
+	if err := null.Scan([]byte("08:30:00-05")); err != nil || !null.Valid || null.TimeTZ.Offset != -5*3600 {
+		t.Errorf("unexpected scan result %+v, %v", null, err)
+	}
+	if v, err := (NullTime{}).Value(); v != nil || err != nil {
+		t.Errorf("expected NULL, got %v, %v", v, err)
+	}
+
+	encoded, err := json.Marshal(struct {
+		Opens  Time
+		Closes TimeTZ
+	}{tm, TimeTZ{Time: Time{Hour: 21}, Offset: -5 * 3600}})
+	if err != nil || string(encoded) != `{"Opens":"08:30:01.000002","Closes":"21:00:00-05"}` {
+		t.Errorf("unexpected JSON %s, %v", encoded, err)
+	}
+}
+
+func TestTypedTimeColumns(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "t", DataTypeOID: common.ColTypeTime, DataTypeName: "time"},
+		{FieldName: "tz", DataTypeOID: common.ColTypeTimeTZ, DataTypeName: "timetz"},
+	}}
+	result := make([]driver.Value, 2)
+
+	rows := newTestRows(t, desc, []string{"08:30:00", "08:30:00-05"})
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := result[0].(time.Time); !ok {
+		t.Errorf("expected TIME to default to time.Time, got %T", result[0])
+	}
+
+	rows = newTestRows(t, desc, []string{"08:30:00", "08:30:00-05"})
+	rows.typedTime = true
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []driver.Value{Time{Hour: 8, Minute: 30}, TimeTZ{Time: Time{Hour: 8, Minute: 30}, Offset: -5 * 3600}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+	if scanType := rows.ColumnTypeScanType(1); scanType != reflect.TypeOf(NullTimeTZ{}) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+
+	col := &msgs.BERowDescColumnDef{DataTypeOID: common.ColTypeTime, FormatCode: formatBinary}
+	if v, err := rows.decodeBinary(col, int64Bytes(8*microsPerHour+30*microsPerMinute)); err != nil || v != expected[0] {
+		t.Errorf("expected %v from binary, got %v, %v", expected[0], v, err)
+	}
+
+	args := []driver.NamedValue{{Ordinal: 1, Value: expected[0]}, {Ordinal: 2, Value: expected[1]}}
+	for idx := range args {
+		converted, err := checkBindValue(args[idx].Value, nil)
+		if err != nil || converted != args[idx].Value {
+			t.Fatalf("unexpected bind conversion %v, %v", converted, err)
+		}
+	}
+	encoded, err := encodeBindArgs(args, nil)
+	if err != nil || encoded[0].Value != "08:30:00" || encoded[1].Value != "08:30:00-05" {
+		t.Errorf("unexpected encoded arguments %v, %v", encoded, err)
+	}
+}
@@ -0,0 +1,212 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+// TypeDecoder converts the text the server sends for a column value into its
+// Go value. It is not called for NULL.
+type TypeDecoder func(value []byte) (driver.Value, error)
+
+// TypeEncoder converts a query argument of a registered Go type into a value
+// the driver can send, such as a string, an int64 or a time.Time.
+type TypeEncoder func(value interface{}) (driver.Value, error)
+
+// TypeRegistry holds decoders for column types and encoders for Go types, for
+// types the driver does not know about such as GEOMETRY or a user-defined
+// type. The functions RegisterTypeDecoder and RegisterTypeEncoder register
+// types for every connection. A TypeRegistry set on Config.Types applies to
+// the connections of that Config only, and takes precedence.
+type TypeRegistry struct {
+	mu             sync.RWMutex
+	decodersByOID  map[uint32]*typeDecoder
+	decodersByName map[string]*typeDecoder
+	encoders       map[reflect.Type]TypeEncoder
+}
+
+type typeDecoder struct {
+	decode   TypeDecoder
+	scanType reflect.Type
+}
+
+var defaultTypeRegistry = NewTypeRegistry()
+
+// NewTypeRegistry returns an empty TypeRegistry.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		decodersByOID:  make(map[uint32]*typeDecoder),
+		decodersByName: make(map[string]*typeDecoder),
+		encoders:       make(map[reflect.Type]TypeEncoder),
+	}
+}
+
+// RegisterTypeDecoder registers decode for every connection. See
+// TypeRegistry.RegisterTypeDecoder.
+func RegisterTypeDecoder(oidOrTypeName interface{}, decode TypeDecoder, scanType reflect.Type) error {
+	return defaultTypeRegistry.RegisterTypeDecoder(oidOrTypeName, decode, scanType)
+}
+
+// RegisterTypeEncoder registers encode for every connection. See
+// TypeRegistry.RegisterTypeEncoder.
+func RegisterTypeEncoder(goType reflect.Type, encode TypeEncoder) error {
+	return defaultTypeRegistry.RegisterTypeEncoder(goType, encode)
+}
+
+// RegisterTypeDecoder decodes the columns of a type with decode instead of the
+// built-in conversion. The type is given by its OID as an integer, or by its
+// name as the server reports it, such as "GEOMETRY", matched without regard to
+// case. A decoder registered by OID wins over one registered by name. scanType
+// is what ColumnTypeScanType reports for the columns, and may be nil. A nil
+// decode removes the registration.
+func (tr *TypeRegistry) RegisterTypeDecoder(oidOrTypeName interface{}, decode TypeDecoder, scanType reflect.Type) error {
+	var dec *typeDecoder
+	if decode != nil {
+		dec = &typeDecoder{decode: decode, scanType: scanType}
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	var oid int64
+	switch key := oidOrTypeName.(type) {
+	case string:
+		name := strings.ToUpper(strings.TrimSpace(key))
+		if name == "" {
+			return fmt.Errorf("cannot register a decoder for an empty type name")
+		}
+		if dec == nil {
+			delete(tr.decodersByName, name)
+		} else {
+			tr.decodersByName[name] = dec
+		}
+		return nil
+	case int:
+		oid = int64(key)
+	case int32:
+		oid = int64(key)
+	case int64:
+		oid = key
+	case uint32:
+		oid = int64(key)
+	default:
+		return fmt.Errorf("cannot register a decoder for %T, expected a type OID or name", oidOrTypeName)
+	}
+	if oid <= 0 || oid > int64(^uint32(0)) {
+		return fmt.Errorf("invalid type OID %d", oid)
+	}
+	if dec == nil {
+		delete(tr.decodersByOID, uint32(oid))
+	} else {
+		tr.decodersByOID[uint32(oid)] = dec
+	}
+	return nil
+}
+
+// RegisterTypeEncoder converts query arguments of goType with encode before
+// they are sent. The result is converted by the built-in rules, so it can be
+// any value the driver accepts as an argument other than another registered
+// type. A nil encode removes the registration.
+func (tr *TypeRegistry) RegisterTypeEncoder(goType reflect.Type, encode TypeEncoder) error {
+	if goType == nil {
+		return fmt.Errorf("cannot register an encoder for a nil type")
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if encode == nil {
+		delete(tr.encoders, goType)
+	} else {
+		tr.encoders[goType] = encode
+	}
+	return nil
+}
+
+// lookupDecoder returns the decoder for a column, looking in the registry
+// before the global one. tr may be nil.
+func (tr *TypeRegistry) lookupDecoder(col *msgs.BERowDescColumnDef) *typeDecoder {
+	if tr != nil {
+		if dec := tr.decoder(col); dec != nil {
+			return dec
+		}
+	}
+	if tr != defaultTypeRegistry {
+		return defaultTypeRegistry.decoder(col)
+	}
+	return nil
+}
+
+func (tr *TypeRegistry) decoder(col *msgs.BERowDescColumnDef) *typeDecoder {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	if dec, ok := tr.decodersByOID[col.DataTypeOID]; ok {
+		return dec
+	}
+	if len(tr.decodersByName) == 0 {
+		return nil
+	}
+	return tr.decodersByName[strings.ToUpper(col.DataTypeName)]
+}
+
+// lookupEncoder returns the encoder for an argument, looking in the registry
+// before the global one. tr may be nil.
+func (tr *TypeRegistry) lookupEncoder(value interface{}) TypeEncoder {
+	if value == nil {
+		return nil
+	}
+	goType := reflect.TypeOf(value)
+	if tr != nil {
+		if enc := tr.encoder(goType); enc != nil {
+			return enc
+		}
+	}
+	if tr != defaultTypeRegistry {
+		return defaultTypeRegistry.encoder(goType)
+	}
+	return nil
+}
+
+func (tr *TypeRegistry) encoder(goType reflect.Type) TypeEncoder {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	return tr.encoders[goType]
+}
@@ -0,0 +1,182 @@
+package vertigo
+
+// Copyright (c) 2026 Open Text.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vertica/vertica-sql-go/common"
+	"github.com/vertica/vertica-sql-go/msgs"
+)
+
+type testPoint struct{ X, Y float64 }
+
+func decodeTestPoint(value []byte) (driver.Value, error) {
+	var p testPoint
+	if _, err := fmt.Sscanf(string(value), "POINT (%g %g)", &p.X, &p.Y); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func TestTypeDecoderRegistry(t *testing.T) {
+	desc := &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+		{FieldName: "shape", DataTypeOID: 1000001, DataTypeName: "Geometry"},
+		{FieldName: "n", DataTypeOID: common.ColTypeInt64, DataTypeName: "Integer"},
+		{FieldName: "s", DataTypeOID: common.ColTypeVarChar, DataTypeName: "Varchar"},
+	}}
+	result := make([]driver.Value, 3)
+
+	if err := RegisterTypeDecoder("GEOMETRY", decodeTestPoint, reflect.TypeOf(testPoint{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer RegisterTypeDecoder("GEOMETRY", nil, nil)
+
+	rows := newTestRows(t, desc, []string{"POINT (1 2)", "7", "x"})
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, []driver.Value{testPoint{1, 2}, 7, "x"}) {
+		t.Errorf("unexpected values %v", result)
+	}
+	if scanType := rows.ColumnTypeScanType(0); scanType != reflect.TypeOf(testPoint{}) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+
+	// A connector's registry wins over the global one, and an OID over a name.
+	types := NewTypeRegistry()
+	if err := types.RegisterTypeDecoder(common.ColTypeInt64, func(value []byte) (driver.Value, error) {
+		return "int:" + string(value), nil
+	}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := types.RegisterTypeDecoder("integer", func(value []byte) (driver.Value, error) {
+		return nil, fmt.Errorf("the OID decoder should have been used")
+	}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows = newTestRows(t, desc, []string{"POINT (3 4)", "7", "x"})
+	rows.types = types
+	if err := rows.Next(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, []driver.Value{testPoint{3, 4}, "int:7", "x"}) {
+		t.Errorf("unexpected values %v", result)
+	}
+	if scanType := rows.ColumnTypeScanType(1); scanType != reflect.TypeOf(new(interface{})) {
+		t.Errorf("unexpected scan type %v", scanType)
+	}
+
+	rows = newTestRows(t, desc, []string{"LINESTRING (1 2, 3 4)", "7", "x"})
+	var decodeErr *DecodeError
+	if err := rows.Next(result); !errors.As(err, &decodeErr) || decodeErr.ColumnName != "shape" {
+		t.Errorf("expected a decode error for the shape, got %v", err)
+	}
+
+	for _, key := range []interface{}{"", 0, -1, 1.5} {
+		if err := types.RegisterTypeDecoder(key, decodeTestPoint, nil); err == nil {
+			t.Errorf("expected %#v to be rejected as a type", key)
+		}
+	}
+}
+
+func TestTypeEncoderRegistry(t *testing.T) {
+	if err := RegisterTypeEncoder(reflect.TypeOf(testPoint{}), func(value interface{}) (driver.Value, error) {
+		p := value.(testPoint)
+		return fmt.Sprintf("POINT (%g %g)", p.X, p.Y), nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer RegisterTypeEncoder(reflect.TypeOf(testPoint{}), nil)
+
+	conn := &connection{}
+	nv := driver.NamedValue{Ordinal: 1, Value: testPoint{1, 2}}
+	if err := conn.CheckNamedValue(&nv); err != nil || nv.Value != "POINT (1 2)" {
+		t.Errorf("unexpected conversion %v, %v", nv.Value, err)
+	}
+	nv = driver.NamedValue{Ordinal: 1, Value: []testPoint{{1, 2}, {3, 4}}}
+	if err := conn.CheckNamedValue(&nv); err != nil || !reflect.DeepEqual(nv.Value, Array{"POINT (1 2)", "POINT (3 4)"}) {
+		t.Errorf("unexpected array conversion %v, %v", nv.Value, err)
+	}
+
+	// Encoders of the connection win over the global ones; their result is
+	// converted by the built-in rules.
+	types := NewTypeRegistry()
+	if err := types.RegisterTypeEncoder(reflect.TypeOf(testPoint{}), func(value interface{}) (driver.Value, error) {
+		return uint64(value.(testPoint).X), nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conn.config.Types = types
+	nv = driver.NamedValue{Ordinal: 1, Value: testPoint{5, 6}}
+	if err := conn.CheckNamedValue(&nv); err != nil || nv.Value != int64(5) {
+		t.Errorf("unexpected conversion %v, %v", nv.Value, err)
+	}
+
+	if err := types.RegisterTypeEncoder(reflect.TypeOf(testPoint{}), func(value interface{}) (driver.Value, error) {
+		return struct{}{}, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nv = driver.NamedValue{Ordinal: 1, Value: testPoint{5, 6}}
+	if err := conn.CheckNamedValue(&nv); err == nil || !strings.Contains(err.Error(), "unsupported argument type") {
+		t.Errorf("expected the encoded value to be checked, got %v", err)
+	}
+	if err := types.RegisterTypeEncoder(nil, nil); err == nil {
+		t.Errorf("expected a nil type to be rejected")
+	}
+}
+
+func TestResultFormatsSkipRegisteredTypes(t *testing.T) {
+	types := NewTypeRegistry()
+	if err := types.RegisterTypeDecoder(common.ColTypeInt64, func(value []byte) (driver.Value, error) {
+		return string(value), nil
+	}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := &stmt{
+		conn: &connection{config: Config{BinaryResults: true, Types: types}},
+		lastRowDesc: &msgs.BERowDescMsg{Columns: []*msgs.BERowDescColumnDef{
+			{FieldName: "n", DataTypeOID: common.ColTypeInt64},
+			{FieldName: "f", DataTypeOID: common.ColTypeFloat64},
+		}},
+	}
+	if formats := s.resultFormats(ctx); !reflect.DeepEqual(formats, []uint16{formatText, formatBinary}) {
+		t.Errorf("unexpected result formats %v", formats)
+	}
+}